@@ -0,0 +1,51 @@
+// Package batch implements the wire framing used by the buddy-blocks load
+// tools' /batch requests: a slice of blocks (or the hashes/blocks returned
+// for them) packed back-to-back so several blocks can be PUT or GET in a
+// single round trip instead of one request per block.
+//
+// There is currently no /batch HTTP handler in this repository — the load
+// tools in tests/ are clients only — so Encode/Decode exist purely to frame
+// and parse requests and responses against an external server that
+// implements the same format.
+package batch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Encode frames blocks as [u32 count][(u32 len, bytes) x count].
+func Encode(blocks [][]byte) []byte {
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.BigEndian, uint32(len(blocks)))
+	for _, b := range blocks {
+		binary.Write(buf, binary.BigEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+	return buf.Bytes()
+}
+
+// Decode parses the framing produced by Encode.
+func Decode(data []byte) ([][]byte, error) {
+	r := bytes.NewReader(data)
+	var count uint32
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, fmt.Errorf("batch: reading count: %w", err)
+	}
+
+	blocks := make([][]byte, 0, count)
+	for i := uint32(0); i < count; i++ {
+		var n uint32
+		if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+			return nil, fmt.Errorf("batch: reading frame %d length: %w", i, err)
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, fmt.Errorf("batch: reading frame %d body: %w", i, err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
@@ -0,0 +1,34 @@
+package batch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	blocks := [][]byte{
+		[]byte("first"),
+		[]byte(""),
+		bytes.Repeat([]byte("x"), 1024),
+	}
+
+	decoded, err := Decode(Encode(blocks))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(decoded) != len(blocks) {
+		t.Fatalf("got %d blocks, want %d", len(decoded), len(blocks))
+	}
+	for i := range blocks {
+		if !bytes.Equal(decoded[i], blocks[i]) {
+			t.Fatalf("block %d: got %q, want %q", i, decoded[i], blocks[i])
+		}
+	}
+}
+
+func TestDecodeTruncatedFails(t *testing.T) {
+	framed := Encode([][]byte{[]byte("hello")})
+	if _, err := Decode(framed[:len(framed)-2]); err == nil {
+		t.Fatal("Decode of truncated data succeeded, want error")
+	}
+}
@@ -0,0 +1,65 @@
+// Package bufs provides a pool of reusable, fixed-size block buffers so PUT
+// and GET paths don't allocate a fresh []byte on every request. There is one
+// sync.Pool per supported block size (4 KiB through 512 KiB); Get rounds up
+// to the smallest size class that fits, and Put returns a buffer to its
+// class so a later Get of the same size reuses the backing array instead of
+// calling make.
+package bufs
+
+import "sync"
+
+// sizeClasses are the block sizes the buddy-blocks load tools generate,
+// mirroring the blockSizes table in tests/rps.go.
+var sizeClasses = []int{
+	4 * 1024,
+	8 * 1024,
+	16 * 1024,
+	32 * 1024,
+	64 * 1024,
+	128 * 1024,
+	256 * 1024,
+	512 * 1024,
+}
+
+var pools = func() []sync.Pool {
+	p := make([]sync.Pool, len(sizeClasses))
+	for i, size := range sizeClasses {
+		size := size
+		p[i] = sync.Pool{New: func() interface{} { return make([]byte, size) }}
+	}
+	return p
+}()
+
+// classFor returns the index of the smallest size class that fits size, or
+// -1 if size exceeds every class.
+func classFor(size int) int {
+	for i, s := range sizeClasses {
+		if size <= s {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get returns a buffer of length size. If size matches one of the known
+// block size classes, the buffer comes from that class's pool; otherwise a
+// fresh slice is allocated and Put on it is a no-op.
+func Get(size int) []byte {
+	class := classFor(size)
+	if class == -1 {
+		return make([]byte, size)
+	}
+	buf := pools[class].Get().([]byte)
+	return buf[:size]
+}
+
+// Put returns b to the pool for its size class so a future Get can reuse its
+// backing array. Buffers not obtained from Get (e.g. a sub-slice, or a size
+// with no matching class) are silently dropped.
+func Put(b []byte) {
+	class := classFor(cap(b))
+	if class == -1 || cap(b) != sizeClasses[class] {
+		return
+	}
+	pools[class].Put(b[:cap(b)])
+}
@@ -0,0 +1,59 @@
+package bufs
+
+import "testing"
+
+func TestGetReturnsExactLength(t *testing.T) {
+	for _, size := range sizeClasses {
+		buf := Get(size)
+		if len(buf) != size {
+			t.Fatalf("Get(%d) returned length %d", size, len(buf))
+		}
+	}
+}
+
+func TestGetUnknownSizeFallsBackToAlloc(t *testing.T) {
+	buf := Get(123)
+	if len(buf) != 123 {
+		t.Fatalf("Get(123) returned length %d, want 123", len(buf))
+	}
+	// A buffer outside the known size classes must be a no-op to Put.
+	Put(buf)
+}
+
+func TestGetPutReusesBackingArray(t *testing.T) {
+	const size = 64 * 1024
+	first := Get(size)
+	addr := &first[0]
+	Put(first)
+
+	second := Get(size)
+	if &second[0] != addr {
+		t.Fatalf("Get after Put did not reuse the returned buffer's backing array")
+	}
+}
+
+// BenchmarkGetPutPooled and BenchmarkGetPutUnpooled demonstrate the
+// allocation win bufs is meant to provide: run with
+//
+//	go test ./bufs/ -bench . -benchmem
+//
+// and compare B/op and allocs/op for a 128 KiB block, the size used by the
+// load tools' largest block class.
+const benchBlockSize = 128 * 1024
+
+func BenchmarkGetPutPooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := Get(benchBlockSize)
+		buf[0] = byte(i)
+		Put(buf)
+	}
+}
+
+func BenchmarkGetPutUnpooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := make([]byte, benchBlockSize)
+		buf[0] = byte(i)
+	}
+}
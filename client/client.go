@@ -0,0 +1,295 @@
+// Package client is a reusable buddy-blocks client that talks to a pool of
+// servers instead of a single SERVER_URL. It tracks a moving average of
+// latency and throughput per endpoint, uses weighted selection (weight ∝
+// speed/latency) to pick the next node for each request, and falls back to
+// the next-best endpoint on transport error, non-2xx response, or (for GET)
+// a hash mismatch.
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// emaAlpha is the weight given to the newest latency/throughput sample when
+// updating an endpoint's moving average.
+const emaAlpha = 0.2
+
+// endpointStats tracks a moving average of latency (ms) and throughput
+// (bytes/ms) for one server, used to weight node selection.
+type endpointStats struct {
+	url string
+
+	mu         sync.Mutex
+	latencyMs  float64
+	speedPerMs float64
+	healthy    bool
+}
+
+func (e *endpointStats) record(latency time.Duration, size int, ok bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ms := float64(latency.Microseconds()) / 1000
+	if ms <= 0 {
+		ms = 0.001
+	}
+	speed := float64(size) / ms
+
+	if e.latencyMs == 0 {
+		e.latencyMs = ms
+		e.speedPerMs = speed
+	} else {
+		e.latencyMs = e.latencyMs*(1-emaAlpha) + ms*emaAlpha
+		e.speedPerMs = e.speedPerMs*(1-emaAlpha) + speed*emaAlpha
+	}
+	e.healthy = ok
+}
+
+// weight returns a selection weight proportional to speed/latency. Endpoints
+// that failed their last request get a small positive weight so they keep
+// getting retried occasionally instead of being permanently excluded.
+func (e *endpointStats) weight() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.healthy {
+		return 0.01
+	}
+	if e.latencyMs <= 0 {
+		return 1
+	}
+	if w := e.speedPerMs / e.latencyMs; w > 0 {
+		return w
+	}
+	return 0.01
+}
+
+func (e *endpointStats) snapshot() (latencyMs, speedPerMs float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.latencyMs, e.speedPerMs
+}
+
+// Metrics is a point-in-time snapshot of one endpoint's tracked performance.
+type Metrics struct {
+	Endpoint   string
+	LatencyMs  float64
+	SpeedPerMs float64
+}
+
+// Pool is a client for a set of buddy-blocks servers. It routes each request
+// to the best-scoring endpoint and retries against the next-best endpoint on
+// failure, up to MaxRetries additional attempts.
+type Pool struct {
+	http       *http.Client
+	maxRetries int
+
+	mu        sync.Mutex
+	endpoints []*endpointStats
+	rng       *rand.Rand
+}
+
+// NewPool creates a client pool over endpoints (e.g. "http://host:8080"). A
+// request that fails is retried against up to maxRetries alternate
+// endpoints before the pool gives up.
+func NewPool(endpoints []string, maxRetries int, timeout time.Duration) *Pool {
+	p := &Pool{
+		http:       &http.Client{Timeout: timeout},
+		maxRetries: maxRetries,
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, e := range endpoints {
+		p.endpoints = append(p.endpoints, &endpointStats{url: e, healthy: true})
+	}
+	return p
+}
+
+// Metrics returns a snapshot of the tracked latency/throughput for every
+// endpoint in the pool.
+func (p *Pool) Metrics() []Metrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]Metrics, len(p.endpoints))
+	for i, e := range p.endpoints {
+		lat, speed := e.snapshot()
+		out[i] = Metrics{Endpoint: e.url, LatencyMs: lat, SpeedPerMs: speed}
+	}
+	return out
+}
+
+// pickOrder returns the endpoints not in skip, in a randomized order weighted
+// by speed/latency (best-scoring endpoints are more likely to come first).
+func (p *Pool) pickOrder(skip map[*endpointStats]bool) []*endpointStats {
+	p.mu.Lock()
+	candidates := make([]*endpointStats, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		if !skip[e] {
+			candidates = append(candidates, e)
+		}
+	}
+	p.mu.Unlock()
+
+	order := make([]*endpointStats, 0, len(candidates))
+	for len(candidates) > 0 {
+		weights := make([]float64, len(candidates))
+		total := 0.0
+		for i, e := range candidates {
+			weights[i] = e.weight()
+			total += weights[i]
+		}
+
+		pick := p.rng.Float64() * total
+		idx := len(candidates) - 1
+		for i, w := range weights {
+			pick -= w
+			if pick <= 0 {
+				idx = i
+				break
+			}
+		}
+
+		order = append(order, candidates[idx])
+		candidates = append(candidates[:idx], candidates[idx+1:]...)
+	}
+	return order
+}
+
+// Put writes data to the best-scoring endpoint, retrying against the
+// next-best endpoint on transport error or non-2xx response. It returns the
+// content hash reported by the server.
+func (p *Pool) Put(data []byte) (hash string, err error) {
+	tried := map[*endpointStats]bool{}
+	var lastErr error
+
+	for attempt := 0; attempt <= p.maxRetries; attempt++ {
+		order := p.pickOrder(tried)
+		if len(order) == 0 {
+			break
+		}
+		ep := order[0]
+		tried[ep] = true
+
+		hash, latency, err := p.doPut(ep, data)
+		logAttempt(ep.url, hash, latency, len(data), err)
+		if err == nil {
+			return hash, nil
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("put failed after %d attempt(s): %w", len(tried), lastErr)
+}
+
+func (p *Pool) doPut(ep *endpointStats, data []byte) (hash string, latency time.Duration, err error) {
+	start := time.Now()
+
+	req, err := http.NewRequest(http.MethodPut, ep.url, bytes.NewReader(data))
+	if err != nil {
+		return "", time.Since(start), err
+	}
+	req.ContentLength = int64(len(data))
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		ep.record(time.Since(start), 0, false)
+		return "", time.Since(start), err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	latency = time.Since(start)
+	if err != nil {
+		ep.record(latency, 0, false)
+		return "", latency, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		ep.record(latency, 0, false)
+		return "", latency, fmt.Errorf("%s: status %d", ep.url, resp.StatusCode)
+	}
+
+	hash = string(bytes.TrimSpace(body))
+	ep.record(latency, len(data), true)
+	return hash, latency, nil
+}
+
+// Get fetches the block addressed by hash. The response is hash-verified
+// before success is recorded, so a node that returns corrupt data is
+// penalized in its score even though the HTTP round trip itself succeeded.
+func (p *Pool) Get(hash string) (data []byte, err error) {
+	tried := map[*endpointStats]bool{}
+	var lastErr error
+
+	for attempt := 0; attempt <= p.maxRetries; attempt++ {
+		order := p.pickOrder(tried)
+		if len(order) == 0 {
+			break
+		}
+		ep := order[0]
+		tried[ep] = true
+
+		data, latency, err := p.doGet(ep, hash)
+		logAttempt(ep.url, hash, latency, len(data), err)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("get failed after %d attempt(s): %w", len(tried), lastErr)
+}
+
+func (p *Pool) doGet(ep *endpointStats, hash string) (data []byte, latency time.Duration, err error) {
+	start := time.Now()
+
+	url := fmt.Sprintf("%s/%s", ep.url, hash)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, time.Since(start), err
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		ep.record(time.Since(start), 0, false)
+		return nil, time.Since(start), err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	latency = time.Since(start)
+	if err != nil {
+		ep.record(latency, 0, false)
+		return nil, latency, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		ep.record(latency, 0, false)
+		return nil, latency, fmt.Errorf("%s: status %d", ep.url, resp.StatusCode)
+	}
+
+	sum := sha256.Sum256(body)
+	if got := hex.EncodeToString(sum[:]); got != hash {
+		ep.record(latency, len(body), false)
+		return nil, latency, fmt.Errorf("%s: hash mismatch, expected %s got %s", ep.url, hash, got)
+	}
+
+	ep.record(latency, len(body), true)
+	return body, latency, nil
+}
+
+// logAttempt prints one line per request attempt with the fields an operator
+// needs to spot a slow or misbehaving node: endpoint, content id, latency,
+// effective throughput, and any error.
+func logAttempt(endpoint, cid string, latency time.Duration, size int, err error) {
+	ms := float64(latency.Microseconds()) / 1000
+	var speed float64
+	if ms > 0 {
+		speed = float64(size) / ms
+	}
+	fmt.Printf("endpoint=%s cid=%s latencyMs=%.2f speedPerMs=%.2f err=%v\n", endpoint, cid, ms, speed, err)
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newBlockServer starts an httptest server that behaves like a single
+// buddy-blocks node: PUT stores the body under its sha256 hash and returns
+// the hash; GET /<hash> returns the stored bytes or 404.
+func newBlockServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	store := make(map[string][]byte)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			sum := sha256.Sum256(body)
+			hash := hex.EncodeToString(sum[:])
+			mu.Lock()
+			store[hash] = body
+			mu.Unlock()
+			w.Write([]byte(hash))
+		case http.MethodGet:
+			hash := strings.TrimPrefix(r.URL.Path, "/")
+			mu.Lock()
+			data, ok := store[hash]
+			mu.Unlock()
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(data)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestPoolPutGetRoundTrip(t *testing.T) {
+	srv := newBlockServer(t)
+	defer srv.Close()
+
+	pool := NewPool([]string{srv.URL}, 2, 2*time.Second)
+	data := []byte("hello buddy-blocks")
+
+	hash, err := pool.Put(data)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, err := pool.Get(hash)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Get returned %q, want %q", got, data)
+	}
+}
+
+// TestPoolFailover checks that a request fails over to the next-best
+// endpoint when the first one returns a non-2xx response.
+func TestPoolFailover(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer bad.Close()
+	good := newBlockServer(t)
+	defer good.Close()
+
+	pool := NewPool([]string{bad.URL, good.URL}, 1, 2*time.Second)
+	data := []byte("failover data")
+
+	hash, err := pool.Put(data)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	got, err := pool.Get(hash)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Get returned %q, want %q", got, data)
+	}
+}
+
+// TestPickOrderWeighting checks that pickOrder favors the endpoint with the
+// better speed/latency score.
+func TestPickOrderWeighting(t *testing.T) {
+	pool := NewPool([]string{"http://fast", "http://slow"}, 0, time.Second)
+
+	var fast, slow *endpointStats
+	for _, e := range pool.endpoints {
+		switch e.url {
+		case "http://fast":
+			fast = e
+		case "http://slow":
+			slow = e
+		}
+	}
+	fast.record(1*time.Millisecond, 4096, true)
+	slow.record(50*time.Millisecond, 4096, true)
+
+	const trials = 200
+	fastFirst := 0
+	for i := 0; i < trials; i++ {
+		if order := pool.pickOrder(nil); order[0] == fast {
+			fastFirst++
+		}
+	}
+	if fastFirst < trials*3/4 {
+		t.Fatalf("fast endpoint picked first %d/%d times, want a strong majority", fastFirst, trials)
+	}
+}
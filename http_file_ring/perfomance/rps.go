@@ -4,181 +4,388 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"flag"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"net/http"
-	"os"
-	"strconv"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var (
 	SERVER_URL  = "http://localhost:8080"
 	BLOCK_SIZE  = 4096 * 2 * 2 * 2 * 2 * 2 * 2 * 2
-	ITERATIONS  = 1000
 	REQ_TIMEOUT = 5 * time.Second
 	NUM_BLOCKS  = 2
 )
 
-func main() {
-	// Получаем размер блока из аргумента или используем 4KB по умолчанию
-	// Допустимые размеры: 4, 8, 16, 32, 64, 128, 256, 512 (KB)
-	if len(os.Args) > 1 {
-		size, err := strconv.Atoi(os.Args[1])
-		if err == nil {
-			BLOCK_SIZE = size * 1024
+// Лог-линейная (HDR-style) гистограмма задержек: каждая декада от hdrMinNs до
+// hdrMaxNs делится на hdrDecadeBuckets линейных корзин, что даёт ~3 значащих
+// цифры разрешения независимо от порядка величины задержки.
+const (
+	hdrMinNs         = int64(1_000)          // 1 µs
+	hdrMaxNs         = int64(60_000_000_000) // 60 s
+	hdrDecadeBuckets = 1000                  // ~3 значащих цифры на декаду
+)
+
+type hdrHistogram struct {
+	mu     sync.Mutex
+	counts map[int]uint64
+	total  uint64
+	max    int64
+}
+
+func newHDRHistogram() *hdrHistogram {
+	return &hdrHistogram{counts: make(map[int]uint64)}
+}
+
+func (h *hdrHistogram) bucketFor(ns int64) int {
+	if ns < hdrMinNs {
+		ns = hdrMinNs
+	}
+	if ns > hdrMaxNs {
+		ns = hdrMaxNs
+	}
+	decades := math.Log10(float64(ns) / float64(hdrMinNs))
+	return int(decades * float64(hdrDecadeBuckets))
+}
+
+func (h *hdrHistogram) record(d time.Duration) {
+	ns := int64(d)
+	bucket := h.bucketFor(ns)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[bucket]++
+	h.total++
+	if ns > h.max {
+		h.max = ns
+	}
+}
+
+// percentile returns the latency at or above which only (1-p) of samples
+// fall, reconstructed from the bucket mid-value rather than the raw sample.
+func (h *hdrHistogram) percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.total == 0 {
+		return 0
+	}
+
+	keys := make([]int, 0, len(h.counts))
+	for k := range h.counts {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	target := uint64(math.Ceil(p * float64(h.total)))
+	if target < 1 {
+		target = 1
+	}
+
+	var cum uint64
+	for _, k := range keys {
+		cum += h.counts[k]
+		if cum >= target {
+			ns := float64(hdrMinNs) * math.Pow(10, float64(k)/float64(hdrDecadeBuckets))
+			return time.Duration(ns)
 		}
 	}
+	return time.Duration(h.max)
+}
+
+func (h *hdrHistogram) count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.total
+}
+
+// tokenBucket - простой ограничитель скорости (операций/сек), используемый
+// диспетчером, чтобы -rps ограничивал суммарную нагрузку по всем воркерам.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // операций/сек; <= 0 означает "без ограничения"
+	tokens   float64
+	capacity float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	return &tokenBucket{rate: rate, tokens: rate, capacity: rate, last: time.Now()}
+}
+
+func (tb *tokenBucket) wait() {
+	if tb.rate <= 0 {
+		return
+	}
+	for {
+		tb.mu.Lock()
+		now := time.Now()
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+		tb.last = now
+		if tb.tokens > tb.capacity {
+			tb.tokens = tb.capacity
+		}
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return
+		}
+		sleep := time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+		tb.mu.Unlock()
+		time.Sleep(sleep)
+	}
+}
+
+type opKind int
+
+const (
+	opPut opKind = iota
+	opGet
+)
+
+// hashPool - потокобезопасный набор хешей успешных PUT, из которого воркеры
+// берут случайный ключ для GET-нагрузки.
+type hashPool struct {
+	mu     sync.Mutex
+	hashes []string
+}
+
+func (p *hashPool) add(hash string) {
+	p.mu.Lock()
+	p.hashes = append(p.hashes, hash)
+	p.mu.Unlock()
+}
+
+func (p *hashPool) pick(r *rand.Rand) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.hashes) == 0 {
+		return "", false
+	}
+	return p.hashes[r.Intn(len(p.hashes))], true
+}
+
+func main() {
+	workers := flag.Int("workers", 16, "Количество воркеров")
+	duration := flag.Duration("duration", 30*time.Second, "Длительность измеряемой фазы нагрузки")
+	warmup := flag.Duration("warmup", 5*time.Second, "Длительность прогрева (не учитывается в отчёте)")
+	rps := flag.Float64("rps", 0, "Ограничение скорости, операций/сек (0 = без ограничения)")
+	readRatio := flag.Float64("read-ratio", 0.5, "Доля GET-операций в общем потоке (0..1)")
+	blockSizeKB := flag.Int("block-size", BLOCK_SIZE/1024, "Размер блока, KB")
+	numBlocks := flag.Int("blocks", NUM_BLOCKS, "Количество уникальных блоков для PUT-нагрузки")
+	serverURL := flag.String("server", SERVER_URL, "URL сервера buddy-blocks")
+	flag.Parse()
+
+	BLOCK_SIZE = *blockSizeKB * 1024
+	NUM_BLOCKS = *numBlocks
+	SERVER_URL = *serverURL
 
 	fmt.Println("============================================================")
-	fmt.Println("Тестирование HTTP сервера - PUT/GET с проверкой данных (Go)")
+	fmt.Println("Нагрузочный тест HTTP сервера - конкурентный PUT/GET (Go)")
 	fmt.Println("============================================================")
+	fmt.Printf("Воркеров: %d | Прогрев: %s | Измерение: %s | RPS: %v | read-ratio: %.2f\n",
+		*workers, *warmup, *duration, *rps, *readRatio)
 
-	// Генерируем 2 блока
 	fmt.Printf("\nГенерация %d блоков данных по %dKB...\n", NUM_BLOCKS, BLOCK_SIZE/1024)
-
 	blocks := make([][]byte, NUM_BLOCKS)
-	hashes := make([]string, NUM_BLOCKS)
-
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
+	r0 := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for i := 0; i < NUM_BLOCKS; i++ {
 		blocks[i] = make([]byte, BLOCK_SIZE)
-		r.Read(blocks[i])
-
-		hashSum := sha256.Sum256(blocks[i])
-		hashes[i] = hex.EncodeToString(hashSum[:])
+		r0.Read(blocks[i])
 	}
-
 	fmt.Println("Блоки сгенерированы")
 
-	// HTTP клиент с ОДНИМ соединением
 	client := &http.Client{
 		Timeout: REQ_TIMEOUT,
 		Transport: &http.Transport{
-			MaxIdleConnsPerHost: 1,
-			MaxIdleConns:        1,
-			MaxConnsPerHost:     1,
+			MaxIdleConnsPerHost: *workers,
+			MaxConnsPerHost:     *workers,
 			IdleConnTimeout:     30 * time.Second,
-			DisableKeepAlives:   false,
 		},
 	}
 
-	putSuccess := 0
-	getSuccess := 0
-	putErrors := 0
-	getErrors := 0
-	hashMismatch := 0
-	dataMismatch := 0
+	var putSuccess, getSuccess, putErrors, getErrors, hashMismatch uint64
+	var recording atomic.Bool
+	putHist := newHDRHistogram()
+	getHist := newHDRHistogram()
+	hashes := &hashPool{}
 
-	fmt.Printf("\nЗапуск циклов PUT/GET для %d блоков (%d итераций)...\n", NUM_BLOCKS, ITERATIONS)
-
-	startTime := time.Now()
-
-	for i := 0; i < ITERATIONS; i++ {
-		blockIdx := i % NUM_BLOCKS
-		block := blocks[blockIdx]
-		expectedHash := hashes[blockIdx]
-
-		// PUT запрос
-		req, err := http.NewRequest("PUT", SERVER_URL, bytes.NewReader(block))
-		if err != nil {
-			fmt.Printf("  ✗ PUT ошибка создания запроса (итерация %d, блок %d): %v\n", i+1, blockIdx, err)
-			putErrors++
-			continue
-		}
-		req.ContentLength = int64(len(block))
-
-		resp, err := client.Do(req)
-		if err != nil {
-			fmt.Printf("  ✗ PUT ошибка запроса (итерация %d, блок %d): %v\n", i+1, blockIdx, err)
-			putErrors++
-			continue
-		}
+	ops := make(chan opKind, *workers*2)
+	limiter := newTokenBucket(*rps)
 
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
+	stop := make(chan struct{})
 
-		if err != nil {
-			fmt.Printf("  ✗ PUT ошибка чтения ответа (итерация %d, блок %d): %v\n", i+1, blockIdx, err)
-			putErrors++
-			continue
-		}
+	// Диспетчер: решает, PUT это или GET (по read-ratio), и подаёт операции
+	// воркерам через общий канал с учётом ограничения rps.
+	go func() {
+		defer close(ops)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			limiter.wait()
 
-		if resp.StatusCode != 200 {
-			fmt.Printf("  ✗ PUT HTTP ошибка (итерация %d, блок %d): %d\n", i+1, blockIdx, resp.StatusCode)
-			putErrors++
-			continue
+			kind := opPut
+			if _, ok := hashes.pick(r0); ok && r0.Float64() < *readRatio {
+				kind = opGet
+			}
+			select {
+			case ops <- kind:
+			case <-stop:
+				return
+			}
 		}
+	}()
 
-		returnedHash := string(bytes.TrimSpace(body))
-		if returnedHash != expectedHash {
-			fmt.Printf("  ✗ PUT хеш не совпадает (итерация %d, блок %d)! Ожидали: %s, получили: %s\n", i+1, blockIdx, expectedHash, returnedHash)
-			hashMismatch++
-			continue
-		}
+	var wg sync.WaitGroup
+	wg.Add(*workers)
+	for w := 0; w < *workers; w++ {
+		workerID := w
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
 
-		putSuccess++
+			for kind := range ops {
+				switch kind {
+				case opPut:
+					block := blocks[r.Intn(len(blocks))]
+					expectedHash := hashOf(block)
 
-		// GET запрос - сразу после PUT читаем тот же блок по полученному хешу
-		getURL := fmt.Sprintf("%s/%s", SERVER_URL, returnedHash)
-		getReq, err := http.NewRequest("GET", getURL, nil)
-		if err != nil {
-			fmt.Printf("  ✗ GET ошибка создания запроса (итерация %d, блок %d): %v\n", i+1, blockIdx, err)
-			getErrors++
-			continue
-		}
+					opStart := time.Now()
+					returnedHash, err := doPut(client, block)
+					elapsed := time.Since(opStart)
 
-		getResp, err := client.Do(getReq)
-		if err != nil {
-			fmt.Printf("  ✗ GET ошибка запроса (итерация %d, блок %d): %v\n", i+1, blockIdx, err)
-			getErrors++
-			continue
-		}
+					if err != nil {
+						atomic.AddUint64(&putErrors, 1)
+						continue
+					}
+					if returnedHash != expectedHash {
+						atomic.AddUint64(&hashMismatch, 1)
+						continue
+					}
+					atomic.AddUint64(&putSuccess, 1)
+					hashes.add(returnedHash)
+					if recording.Load() {
+						putHist.record(elapsed)
+					}
 
-		retrievedData, err := io.ReadAll(getResp.Body)
-		getResp.Body.Close()
+				case opGet:
+					hash, ok := hashes.pick(r)
+					if !ok {
+						continue
+					}
+					opStart := time.Now()
+					_, err := doGet(client, hash)
+					elapsed := time.Since(opStart)
 
-		if err != nil {
-			fmt.Printf("  ✗ GET ошибка чтения данных (итерация %д, блок %d): %v\n", i+1, blockIdx, err)
-			getErrors++
-			continue
-		}
+					if err != nil {
+						atomic.AddUint64(&getErrors, 1)
+						continue
+					}
+					atomic.AddUint64(&getSuccess, 1)
+					if recording.Load() {
+						getHist.record(elapsed)
+					}
+				}
+			}
+		}()
+	}
 
-		if getResp.StatusCode != 200 {
-			fmt.Printf("  ✗ GET HTTP ошибка (итерация %d, блок %d): %d\n", i+1, blockIdx, getResp.StatusCode)
-			getErrors++
-			continue
-		}
+	fmt.Printf("\nПрогрев (%s)...\n", *warmup)
+	time.Sleep(*warmup)
+	recording.Store(true)
 
-		// Проверяем целостность данных
-		if !bytes.Equal(retrievedData, block) {
-			fmt.Printf("  ✗ GET данные не совпадают (итерация %d, блок %d)! Размер: ожидали %d, получили %d\n", i+1, blockIdx, len(block), len(retrievedData))
-			dataMismatch++
-			continue
-		}
+	fmt.Printf("Измерение (%s)...\n", *duration)
+	startTime := time.Now()
+	time.Sleep(*duration)
+	close(stop)
+	elapsed := time.Since(startTime)
 
-		getSuccess++
-	}
+	wg.Wait()
 
-	elapsed := time.Since(startTime)
-	totalOps := putSuccess + getSuccess
-	totalErrors := putErrors + getErrors + hashMismatch + dataMismatch
+	totalOps := atomic.LoadUint64(&putSuccess) + atomic.LoadUint64(&getSuccess)
+	totalErrors := atomic.LoadUint64(&putErrors) + atomic.LoadUint64(&getErrors) + atomic.LoadUint64(&hashMismatch)
 
 	fmt.Println("\n============================================================")
 	fmt.Printf("Тестирование завершено\n")
-	fmt.Printf("PUT успешно: %d\n", putSuccess)
-	fmt.Printf("GET успешно: %d\n", getSuccess)
-	fmt.Printf("Всего успешных операций: %d\n", totalOps)
-	fmt.Printf("PUT ошибок: %d\n", putErrors)
-	fmt.Printf("GET ошибок: %d\n", getErrors)
-	fmt.Printf("Несовпадений хеша: %d\n", hashMismatch)
-	fmt.Printf("Несовпадений данных: %d\n", dataMismatch)
-	fmt.Printf("Всего ошибок: %d\n", totalErrors)
-	fmt.Printf("Всего итераций: %d (ожидалось операций: %d)\n", ITERATIONS, ITERATIONS*2)
-	fmt.Printf("Время выполнения: %.2f секунд\n", elapsed.Seconds())
+	fmt.Printf("PUT успешно: %d | ошибок: %d\n", atomic.LoadUint64(&putSuccess), atomic.LoadUint64(&putErrors))
+	fmt.Printf("GET успешно: %d | ошибок: %d\n", atomic.LoadUint64(&getSuccess), atomic.LoadUint64(&getErrors))
+	fmt.Printf("Несовпадений хеша: %d\n", atomic.LoadUint64(&hashMismatch))
+	fmt.Printf("Всего успешных операций: %d | всего ошибок: %d\n", totalOps, totalErrors)
+	fmt.Printf("Время измерения: %.2f секунд\n", elapsed.Seconds())
 	fmt.Printf("Скорость: %.2f операций/сек\n", float64(totalOps)/elapsed.Seconds())
-	fmt.Printf("Пропускная способность: %.2f МБ/сек\n", float64(totalOps*BLOCK_SIZE)/(1024*1024*elapsed.Seconds()))
+	fmt.Printf("Пропускная способность: %.2f МБ/сек\n", (float64(totalOps)*float64(BLOCK_SIZE))/(1024*1024*elapsed.Seconds()))
+
+	fmt.Println("\nЗадержки PUT:")
+	printLatencyLine(putHist)
+	fmt.Println("Задержки GET:")
+	printLatencyLine(getHist)
 	fmt.Println("============================================================")
 }
+
+func printLatencyLine(h *hdrHistogram) {
+	if h.count() == 0 {
+		fmt.Println("  нет данных")
+		return
+	}
+	fmt.Printf("  p50=%v p90=%v p99=%v p999=%v max=%v (n=%d)\n",
+		h.percentile(0.50), h.percentile(0.90), h.percentile(0.99), h.percentile(0.999),
+		time.Duration(h.max), h.count())
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func doPut(client *http.Client, block []byte) (string, error) {
+	req, err := http.NewRequest("PUT", SERVER_URL, bytes.NewReader(block))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = int64(len(block))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("PUT status %d", resp.StatusCode)
+	}
+
+	return string(bytes.TrimSpace(body)), nil
+}
+
+func doGet(client *http.Client, hash string) ([]byte, error) {
+	getURL := fmt.Sprintf("%s/%s", SERVER_URL, hash)
+	req, err := http.NewRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("GET status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
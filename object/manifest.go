@@ -0,0 +1,85 @@
+// Package object implements a Merkle-chunked object API on top of the raw
+// buddy-blocks block PUT/GET, so callers can store and retrieve content
+// larger than a single block without changing the on-wire block protocol.
+package object
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// BlockSize is the chunk size PutObject splits input into, matching the
+// block size buddy-blocks servers store natively.
+const BlockSize = 256 * 1024
+
+const manifestVersion = 1
+
+// entryKind says whether a manifest's entries reference raw data chunks or
+// nested sub-manifests, so GetObject knows whether to fetch-and-emit or
+// fetch-and-recurse.
+type entryKind uint8
+
+const (
+	kindChunk    entryKind = 0
+	kindManifest entryKind = 1
+)
+
+// manifestEntry is one {hash, size} pair in a manifest, in on-wire form.
+type manifestEntry struct {
+	hash [sha256.Size]byte
+	size uint32
+}
+
+const (
+	manifestHeaderSize = 1 + 1 + 4       // version, kind, count
+	manifestEntrySize  = sha256.Size + 4 // hash, size
+)
+
+// manifestCapacity is the number of entries a manifest can hold while still
+// serializing to at most BlockSize bytes.
+func manifestCapacity() int {
+	return (BlockSize - manifestHeaderSize) / manifestEntrySize
+}
+
+// encodeManifest serializes kind and entries as a length-prefixed binary
+// blob: [version byte][kind byte][u32 count][(32-byte hash, u32 size) x count].
+func encodeManifest(kind entryKind, entries []manifestEntry) []byte {
+	buf := make([]byte, manifestHeaderSize+len(entries)*manifestEntrySize)
+	buf[0] = manifestVersion
+	buf[1] = byte(kind)
+	binary.BigEndian.PutUint32(buf[2:6], uint32(len(entries)))
+
+	off := manifestHeaderSize
+	for _, e := range entries {
+		copy(buf[off:], e.hash[:])
+		binary.BigEndian.PutUint32(buf[off+sha256.Size:], e.size)
+		off += manifestEntrySize
+	}
+	return buf
+}
+
+func decodeManifest(data []byte) (kind entryKind, entries []manifestEntry, err error) {
+	if len(data) < manifestHeaderSize {
+		return 0, nil, fmt.Errorf("object: manifest too short: %d bytes", len(data))
+	}
+	if data[0] != manifestVersion {
+		return 0, nil, fmt.Errorf("object: unsupported manifest version %d", data[0])
+	}
+
+	kind = entryKind(data[1])
+	count := binary.BigEndian.Uint32(data[2:6])
+	want := manifestHeaderSize + int(count)*manifestEntrySize
+	if len(data) != want {
+		return 0, nil, fmt.Errorf("object: manifest length mismatch: got %d want %d", len(data), want)
+	}
+
+	entries = make([]manifestEntry, count)
+	off := manifestHeaderSize
+	for i := range entries {
+		copy(entries[i].hash[:], data[off:off+sha256.Size])
+		entries[i].size = binary.BigEndian.Uint32(data[off+sha256.Size:])
+		off += manifestEntrySize
+	}
+	return kind, entries, nil
+}
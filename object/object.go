@@ -0,0 +1,153 @@
+package object
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Store is the minimal block interface PutObject/GetObject need: content
+// addressed PUT with dedup, and GET by hash.
+type Store interface {
+	Put(data []byte) (hash string, err error)
+	Get(hash string) (data []byte, err error)
+}
+
+// PutObject reads r in BlockSize chunks, PUTs each distinct chunk to store
+// (identical chunks are only written once), and builds a manifest listing
+// them in order. The manifest is itself PUT, and its hash becomes the
+// returned object root. A manifest whose serialized form would exceed
+// BlockSize is split into nested sub-manifests instead, so arbitrarily large
+// objects produce a Merkle tree rather than one oversized manifest block.
+func PutObject(store Store, r io.Reader) (root string, err error) {
+	seen := make(map[[sha256.Size]byte]bool)
+	var entries []manifestEntry
+
+	buf := make([]byte, BlockSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			chunk := buf[:n]
+			sum := sha256.Sum256(chunk)
+			if !seen[sum] {
+				if _, err := store.Put(chunk); err != nil {
+					return "", fmt.Errorf("object: put chunk: %w", err)
+				}
+				seen[sum] = true
+			}
+			entries = append(entries, manifestEntry{hash: sum, size: uint32(n)})
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return "", fmt.Errorf("object: read: %w", readErr)
+		}
+	}
+
+	return putManifest(store, kindChunk, entries)
+}
+
+// putManifest PUTs entries as a single manifest of kind if they fit within
+// BlockSize, or otherwise splits them into groups that do fit, PUTs each
+// group as its own sub-manifest of kind, and recurses to build a parent
+// manifest of kindManifest over the sub-manifest hashes.
+func putManifest(store Store, kind entryKind, entries []manifestEntry) (string, error) {
+	if len(encodeManifest(kind, entries)) <= BlockSize {
+		hash, err := store.Put(encodeManifest(kind, entries))
+		if err != nil {
+			return "", fmt.Errorf("object: put manifest: %w", err)
+		}
+		return hash, nil
+	}
+
+	maxPerGroup := manifestCapacity()
+	var subEntries []manifestEntry
+	for i := 0; i < len(entries); i += maxPerGroup {
+		end := i + maxPerGroup
+		if end > len(entries) {
+			end = len(entries)
+		}
+		group := entries[i:end]
+
+		subHash, err := putManifest(store, kind, group)
+		if err != nil {
+			return "", err
+		}
+		hashBytes, err := decodeHash(subHash)
+		if err != nil {
+			return "", err
+		}
+		subEntries = append(subEntries, manifestEntry{hash: hashBytes, size: uint32(len(encodeManifest(kind, group)))})
+	}
+
+	return putManifest(store, kindManifest, subEntries)
+}
+
+// GetObject fetches the manifest addressed by root, then fetches and writes
+// every referenced chunk to w in order, verifying each chunk's hash (and, for
+// nested manifests, each sub-manifest's hash) before using its contents.
+func GetObject(store Store, root string, w io.Writer) error {
+	return fetchManifest(store, root, w)
+}
+
+func fetchManifest(store Store, hash string, w io.Writer) error {
+	data, err := store.Get(hash)
+	if err != nil {
+		return fmt.Errorf("object: get manifest %s: %w", hash, err)
+	}
+	if err := verifyHash(hash, data); err != nil {
+		return err
+	}
+
+	kind, entries, err := decodeManifest(data)
+	if err != nil {
+		return fmt.Errorf("object: decode manifest %s: %w", hash, err)
+	}
+
+	for _, e := range entries {
+		childHash := hex.EncodeToString(e.hash[:])
+		switch kind {
+		case kindManifest:
+			if err := fetchManifest(store, childHash, w); err != nil {
+				return err
+			}
+		case kindChunk:
+			chunk, err := store.Get(childHash)
+			if err != nil {
+				return fmt.Errorf("object: get chunk %s: %w", childHash, err)
+			}
+			if err := verifyHash(childHash, chunk); err != nil {
+				return err
+			}
+			if uint32(len(chunk)) != e.size {
+				return fmt.Errorf("object: chunk %s size mismatch: got %d want %d", childHash, len(chunk), e.size)
+			}
+			if _, err := w.Write(chunk); err != nil {
+				return fmt.Errorf("object: write chunk %s: %w", childHash, err)
+			}
+		default:
+			return fmt.Errorf("object: unknown manifest entry kind %d", kind)
+		}
+	}
+	return nil
+}
+
+func verifyHash(hash string, data []byte) error {
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != hash {
+		return fmt.Errorf("object: hash mismatch for %s: got %s", hash, got)
+	}
+	return nil
+}
+
+func decodeHash(hash string) ([sha256.Size]byte, error) {
+	var out [sha256.Size]byte
+	decoded, err := hex.DecodeString(hash)
+	if err != nil || len(decoded) != sha256.Size {
+		return out, fmt.Errorf("object: store returned non-sha256 hash %q", hash)
+	}
+	copy(out[:], decoded)
+	return out, nil
+}
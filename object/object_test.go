@@ -0,0 +1,138 @@
+package object
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// fakeStore is an in-memory Store used to verify PutObject/GetObject's
+// chunking, manifest, and dedup logic without a live server.
+type fakeStore struct {
+	mu   sync.Mutex
+	puts int
+	data map[string][]byte
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{data: make(map[string][]byte)}
+}
+
+func (s *fakeStore) Put(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.data[hash]; !exists {
+		cp := make([]byte, len(data))
+		copy(cp, data)
+		s.data[hash] = cp
+	}
+	s.puts++
+	return hash, nil
+}
+
+func (s *fakeStore) Get(hash string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.data[hash]
+	if !ok {
+		return nil, fmt.Errorf("fakeStore: no such hash %s", hash)
+	}
+	return data, nil
+}
+
+func TestPutGetObjectSingleChunk(t *testing.T) {
+	store := newFakeStore()
+	content := bytes.Repeat([]byte("x"), 1024)
+
+	root, err := PutObject(store, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := GetObject(store, root, &out); err != nil {
+		t.Fatalf("GetObject: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), content) {
+		t.Fatalf("round-tripped content differs: got %d bytes, want %d", out.Len(), len(content))
+	}
+}
+
+func TestPutGetObjectMultiChunk(t *testing.T) {
+	store := newFakeStore()
+	// A few full BlockSize chunks plus a short final chunk.
+	content := make([]byte, BlockSize*3+500)
+	for i := range content {
+		content[i] = byte(i)
+	}
+
+	root, err := PutObject(store, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := GetObject(store, root, &out); err != nil {
+		t.Fatalf("GetObject: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), content) {
+		t.Fatalf("round-tripped content differs from original")
+	}
+}
+
+func TestPutObjectDedupsRepeatedChunks(t *testing.T) {
+	store := newFakeStore()
+	chunk := bytes.Repeat([]byte("a"), BlockSize)
+	content := append(append([]byte{}, chunk...), chunk...) // two identical chunks
+
+	if _, err := PutObject(store, bytes.NewReader(content)); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	// One Put for the identical chunk, one for the manifest.
+	if store.puts != 2 {
+		t.Fatalf("store.puts = %d, want 2 (chunk deduped, manifest written once)", store.puts)
+	}
+}
+
+// TestNestedManifest forces putManifest/fetchManifest to build and walk a
+// manifest of manifests by feeding it more entries than fit in one BlockSize
+// manifest, without needing to actually PUT gigabytes of chunk data.
+func TestNestedManifest(t *testing.T) {
+	store := newFakeStore()
+
+	n := manifestCapacity() + 25
+	entries := make([]manifestEntry, n)
+	var want bytes.Buffer
+	for i := 0; i < n; i++ {
+		chunk := []byte(fmt.Sprintf("chunk-%06d", i))
+		hash, err := store.Put(chunk)
+		if err != nil {
+			t.Fatalf("seeding chunk %d: %v", i, err)
+		}
+		hashBytes, err := decodeHash(hash)
+		if err != nil {
+			t.Fatalf("decodeHash: %v", err)
+		}
+		entries[i] = manifestEntry{hash: hashBytes, size: uint32(len(chunk))}
+		want.Write(chunk)
+	}
+
+	root, err := putManifest(store, kindChunk, entries)
+	if err != nil {
+		t.Fatalf("putManifest: %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := fetchManifest(store, root, &got); err != nil {
+		t.Fatalf("fetchManifest: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), want.Bytes()) {
+		t.Fatalf("nested manifest round-trip mismatch: got %d bytes, want %d", got.Len(), want.Len())
+	}
+}
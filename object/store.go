@@ -0,0 +1,16 @@
+package object
+
+import (
+	"github.com/solenopsys/buddy-blocks/client"
+	"github.com/solenopsys/buddy-blocks/sharding"
+)
+
+// client.Pool (see package client) and sharding.Client (see package sharding)
+// already expose a Put(data []byte) (string, error) / Get(hash string)
+// ([]byte, error) pair that matches Store exactly, so either can be passed to
+// PutObject/GetObject without a wrapper. These assertions keep that true at
+// compile time.
+var (
+	_ Store = (*client.Pool)(nil)
+	_ Store = (*sharding.Client)(nil)
+)
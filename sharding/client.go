@@ -0,0 +1,115 @@
+package sharding
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Client distributes PUT/GET across a Ring of buddy-blocks servers, keyed by
+// the block's SHA-256 hash.
+type Client struct {
+	ring     *Ring
+	replicas int // R: endpoints (primary + successors) each block is written to
+	http     *http.Client
+}
+
+// NewClient wraps ring with a client that replicates every PUT to
+// replicationFactor endpoints (the primary owner plus its successors).
+func NewClient(ring *Ring, replicationFactor int, timeout time.Duration) *Client {
+	return &Client{ring: ring, replicas: replicationFactor, http: &http.Client{Timeout: timeout}}
+}
+
+// Put hashes data, writes it to the primary owner and its R-1 successors,
+// and returns the resulting content hash. It succeeds as long as at least one
+// target accepted the write.
+func (c *Client) Put(data []byte) (hash string, err error) {
+	sum := sha256.Sum256(data)
+	hash = hex.EncodeToString(sum[:])
+
+	targets := c.ring.Successors(hash, c.replicas)
+	if len(targets) == 0 {
+		return "", fmt.Errorf("sharding: no endpoints in ring")
+	}
+
+	var lastErr error
+	wrote := 0
+	for _, endpoint := range targets {
+		if err := putTo(c.http, endpoint, data); err != nil {
+			lastErr = err
+			continue
+		}
+		wrote++
+	}
+	if wrote == 0 {
+		return "", fmt.Errorf("sharding: put failed on all %d target(s): %w", len(targets), lastErr)
+	}
+	return hash, nil
+}
+
+// Get fetches hash from its primary owner, walking successors on a missing
+// block (404) so a read still succeeds after a node has left the ring.
+func (c *Client) Get(hash string) ([]byte, error) {
+	targets := c.ring.Successors(hash, c.replicas)
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("sharding: no endpoints in ring")
+	}
+
+	var lastErr error
+	for _, endpoint := range targets {
+		data, status, err := getFrom(c.http, endpoint, hash)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if status < 200 || status >= 300 {
+			lastErr = fmt.Errorf("%s: status %d", endpoint, status)
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != hash {
+			lastErr = fmt.Errorf("%s: hash mismatch, expected %s got %s", endpoint, hash, got)
+			continue
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("sharding: get failed on all %d target(s): %w", len(targets), lastErr)
+}
+
+func putTo(client *http.Client, endpoint string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(data))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: status %d", endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+func getFrom(client *http.Client, endpoint, hash string) (data []byte, status int, err error) {
+	resp, err := client.Get(fmt.Sprintf("%s/%s", endpoint, hash))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return body, resp.StatusCode, nil
+}
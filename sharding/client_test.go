@@ -0,0 +1,148 @@
+package sharding
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newBlockNode starts an httptest server behaving like one buddy-blocks node:
+// PUT stores the body under its sha256 hash, GET /<hash> returns it or 404s.
+func newBlockNode(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	store := make(map[string][]byte)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			sum := sha256.Sum256(body)
+			hash := hex.EncodeToString(sum[:])
+			mu.Lock()
+			store[hash] = body
+			mu.Unlock()
+			w.Write([]byte(hash))
+		case http.MethodGet:
+			hash := strings.TrimPrefix(r.URL.Path, "/")
+			mu.Lock()
+			data, ok := store[hash]
+			mu.Unlock()
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(data)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestClientPutGetRoundTrip(t *testing.T) {
+	nodes := make([]*httptest.Server, 3)
+	ring := NewRing(50)
+	for i := range nodes {
+		nodes[i] = newBlockNode(t)
+		defer nodes[i].Close()
+		ring.Add(nodes[i].URL)
+	}
+
+	c := NewClient(ring, 2, 2*time.Second)
+	data := []byte("sharded block data")
+
+	hash, err := c.Put(data)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, err := c.Get(hash)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Get returned %q, want %q", got, data)
+	}
+}
+
+// TestClientGetWalksSuccessorsOnMissingPrimary checks that Get falls back to
+// a successor when the primary owner doesn't have the block (e.g. it left
+// and rejoined the ring empty).
+func TestClientGetWalksSuccessorsOnMissingPrimary(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer primary.Close()
+	backup := newBlockNode(t)
+	defer backup.Close()
+
+	ring := NewRing(50)
+	ring.Add(primary.URL)
+	ring.Add(backup.URL)
+
+	c := NewClient(ring, 2, 2*time.Second)
+	data := []byte("replicated elsewhere")
+
+	req, _ := http.NewRequest(http.MethodPut, backup.URL+"/", strings.NewReader(string(data)))
+	req.ContentLength = int64(len(data))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("seeding backup node: %v", err)
+	}
+	hashBytes, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	hash := string(hashBytes)
+
+	got, err := c.Get(hash)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Get returned %q, want %q", got, data)
+	}
+}
+
+// TestClientGetRejectsCorruptedPrimary checks that Get treats a hash mismatch
+// the same as a missing block: it's rejected rather than returned, and Get
+// falls back to a successor that has the real data.
+func TestClientGetRejectsCorruptedPrimary(t *testing.T) {
+	corrupted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not the block you asked for"))
+	}))
+	defer corrupted.Close()
+	good := newBlockNode(t)
+	defer good.Close()
+
+	ring := NewRing(50)
+	ring.Add(corrupted.URL)
+	ring.Add(good.URL)
+
+	c := NewClient(ring, 2, 2*time.Second)
+	data := []byte("replicated elsewhere")
+
+	req, _ := http.NewRequest(http.MethodPut, good.URL+"/", strings.NewReader(string(data)))
+	req.ContentLength = int64(len(data))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("seeding good node: %v", err)
+	}
+	hashBytes2, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	hash2 := string(hashBytes2)
+
+	got, err := c.Get(hash2)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Get returned %q, want %q", got, data)
+	}
+}
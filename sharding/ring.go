@@ -0,0 +1,105 @@
+// Package sharding implements consistent-hash key placement over a cluster
+// of buddy-blocks servers, so PUT/GET can be routed by a block's SHA-256
+// hash without a central coordinator.
+package sharding
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+// Ring is a classic Karger-style consistent hash ring. Each node contributes
+// `replicas` virtual nodes so keys redistribute roughly 1/N when a node is
+// added or removed, instead of the whole ring reshuffling.
+type Ring struct {
+	replicas int
+	nodes    map[uint32]string // ring position -> endpoint
+	sorted   []uint32          // positions, sorted ascending
+}
+
+// NewRing creates an empty ring in which every node gets `replicas` virtual
+// nodes.
+func NewRing(replicas int) *Ring {
+	return &Ring{replicas: replicas, nodes: make(map[uint32]string)}
+}
+
+func virtualNodePos(i int, node string) uint32 {
+	return crc32.ChecksumIEEE([]byte(strconv.Itoa(i) + node))
+}
+
+// Add inserts node's virtual nodes into the ring. Re-adding an already
+// present node is a no-op for its existing virtual nodes.
+func (r *Ring) Add(node string) {
+	changed := false
+	for i := 0; i < r.replicas; i++ {
+		pos := virtualNodePos(i, node)
+		if _, exists := r.nodes[pos]; exists {
+			continue
+		}
+		r.nodes[pos] = node
+		changed = true
+	}
+	if changed {
+		r.resort()
+	}
+}
+
+// Remove deletes all of node's virtual nodes from the ring.
+func (r *Ring) Remove(node string) {
+	for i := 0; i < r.replicas; i++ {
+		delete(r.nodes, virtualNodePos(i, node))
+	}
+	r.resort()
+}
+
+func (r *Ring) resort() {
+	sorted := make([]uint32, 0, len(r.nodes))
+	for pos := range r.nodes {
+		sorted = append(sorted, pos)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	r.sorted = sorted
+}
+
+// Get returns the primary owner of key: the node whose virtual node position
+// is the first at or after hash(key), wrapping to index 0 if none is found.
+func (r *Ring) Get(key string) string {
+	if len(r.sorted) == 0 {
+		return ""
+	}
+	idx := r.indexFor(key)
+	return r.nodes[r.sorted[idx]]
+}
+
+// Successors returns up to n distinct endpoints starting at key's primary
+// owner and walking clockwise around the ring. It is used both to pick
+// replication targets for PUT and as the GET fallback order when the primary
+// is missing a block.
+func (r *Ring) Successors(key string, n int) []string {
+	if len(r.sorted) == 0 || n <= 0 {
+		return nil
+	}
+	start := r.indexFor(key)
+
+	seen := make(map[string]bool, n)
+	out := make([]string, 0, n)
+	for i := 0; i < len(r.sorted) && len(out) < n; i++ {
+		node := r.nodes[r.sorted[(start+i)%len(r.sorted)]]
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		out = append(out, node)
+	}
+	return out
+}
+
+func (r *Ring) indexFor(key string) int {
+	h := crc32.ChecksumIEEE([]byte(key))
+	idx := sort.Search(len(r.sorted), func(i int) bool { return r.sorted[i] >= h })
+	if idx == len(r.sorted) {
+		idx = 0
+	}
+	return idx
+}
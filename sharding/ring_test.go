@@ -0,0 +1,98 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestRingRedistributionOnAdd checks the headline property of consistent
+// hashing: adding a node to an N-node ring should only remap roughly 1/(N+1)
+// of keys, not reshuffle the whole keyspace.
+func TestRingRedistributionOnAdd(t *testing.T) {
+	const numNodes = 8
+	const numKeys = 20000
+	const replicas = 100
+
+	r := NewRing(replicas)
+	for i := 0; i < numNodes; i++ {
+		r.Add(fmt.Sprintf("node-%d", i))
+	}
+
+	keys := make([]string, numKeys)
+	before := make([]string, numKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		before[i] = r.Get(keys[i])
+	}
+
+	r.Add("node-new")
+
+	moved := 0
+	for i, key := range keys {
+		if r.Get(key) != before[i] {
+			moved++
+		}
+	}
+
+	got := float64(moved) / float64(numKeys)
+	want := 1.0 / float64(numNodes+1)
+	// Virtual-node hashing only approximates the ideal 1/N fraction; allow a
+	// generous band around it rather than pinning an exact value.
+	if got < want*0.5 || got > want*2 {
+		t.Fatalf("redistribution fraction = %.3f, want close to %.3f (1/%d)", got, want, numNodes+1)
+	}
+}
+
+// TestRingRemoveReturnsKeysToRemainingNodes checks that removing a node only
+// reassigns that node's keys, leaving every other node's keys untouched.
+func TestRingRemoveReturnsKeysToRemainingNodes(t *testing.T) {
+	const numNodes = 6
+	const numKeys = 5000
+	const replicas = 100
+
+	r := NewRing(replicas)
+	for i := 0; i < numNodes; i++ {
+		r.Add(fmt.Sprintf("node-%d", i))
+	}
+
+	keys := make([]string, numKeys)
+	before := make([]string, numKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		before[i] = r.Get(keys[i])
+	}
+
+	r.Remove("node-0")
+
+	for i, key := range keys {
+		after := r.Get(key)
+		if before[i] == "node-0" {
+			if after == "node-0" {
+				t.Fatalf("key %q still owned by removed node-0", key)
+			}
+			continue
+		}
+		if after != before[i] {
+			t.Fatalf("key %q owned by %q moved to %q after an unrelated node was removed", key, before[i], after)
+		}
+	}
+}
+
+func TestRingSuccessorsAreDistinct(t *testing.T) {
+	r := NewRing(50)
+	for i := 0; i < 4; i++ {
+		r.Add(fmt.Sprintf("node-%d", i))
+	}
+
+	succ := r.Successors("some-key", 3)
+	if len(succ) != 3 {
+		t.Fatalf("Successors returned %d nodes, want 3", len(succ))
+	}
+	seen := make(map[string]bool, len(succ))
+	for _, n := range succ {
+		if seen[n] {
+			t.Fatalf("Successors returned duplicate node %q: %v", n, succ)
+		}
+		seen[n] = true
+	}
+}
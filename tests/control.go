@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tokenBucket is a simple token-bucket rate limiter shared across all workers
+// so -rate / POST /rate caps total ops/sec, not per-worker.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // tokens/sec; <= 0 means unlimited
+	tokens   float64
+	capacity float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	return &tokenBucket{rate: rate, tokens: rate, capacity: rate, last: time.Now()}
+}
+
+func (tb *tokenBucket) setRate(rate float64) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.rate = rate
+	tb.capacity = rate
+	if tb.tokens > tb.capacity {
+		tb.tokens = tb.capacity
+	}
+}
+
+// wait blocks until a token is available, or returns immediately if the rate
+// is unlimited (<= 0).
+func (tb *tokenBucket) wait() {
+	for {
+		tb.mu.Lock()
+		if tb.rate <= 0 {
+			tb.mu.Unlock()
+			return
+		}
+		now := time.Now()
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+		tb.last = now
+		if tb.tokens > tb.capacity {
+			tb.tokens = tb.capacity
+		}
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return
+		}
+		sleep := time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+		tb.mu.Unlock()
+		time.Sleep(sleep)
+	}
+}
+
+// controlState is the shared, atomically-accessed state an operator can flip
+// through the control server while a run is in progress.
+type controlState struct {
+	paused  atomic.Bool
+	desired atomic.Int64 // target worker count
+	limiter *tokenBucket
+}
+
+func newControlState(concurrency int, initialRate float64) *controlState {
+	cs := &controlState{limiter: newTokenBucket(initialRate)}
+	cs.desired.Store(int64(concurrency))
+	return cs
+}
+
+// throttle blocks while paused and consumes one rate-limiter token; call it
+// once per iteration before a worker issues its next request.
+func (cs *controlState) throttle(ctx context.Context) {
+	for cs.paused.Load() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	cs.limiter.wait()
+}
+
+// dynamicPool keeps a set of worker goroutines whose count tracks
+// controlState.desired, scaling up or down without restarting the process.
+type dynamicPool struct {
+	mu      sync.Mutex
+	cancels []context.CancelFunc
+}
+
+// reconcile spawns or cancels workers so the running count matches target.
+// spawn is called with a context derived from parent that is cancelled either
+// when parent is cancelled or when this worker is scaled down. wg.Add is
+// called synchronously (before the new goroutine starts) so it can never race
+// a concurrent wg.Wait; reconcile itself never grows the pool once parent is
+// already done.
+func (p *dynamicPool) reconcile(parent context.Context, target int, wg *sync.WaitGroup, spawn func(context.Context)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.cancels) < target && parent.Err() == nil {
+		wctx, cancel := context.WithCancel(parent)
+		p.cancels = append(p.cancels, cancel)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			spawn(wctx)
+		}()
+	}
+	for len(p.cancels) > target {
+		last := len(p.cancels) - 1
+		p.cancels[last]()
+		p.cancels = p.cancels[:last]
+	}
+}
+
+// runControlled scales the pool to ctrl.desired once up front, then keeps it
+// reconciled in the background until ctx is done.
+func runControlled(ctx context.Context, ctrl *controlState, wg *sync.WaitGroup, spawn func(context.Context)) {
+	pool := &dynamicPool{}
+	pool.reconcile(ctx, int(ctrl.desired.Load()), wg, spawn)
+
+	go func() {
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pool.reconcile(ctx, int(ctrl.desired.Load()), wg, spawn)
+			}
+		}
+	}()
+}
+
+// startControlServer exposes pause/resume/rate/concurrency/stats over HTTP so
+// an operator can steer a long-running soak test without restarting it.
+func startControlServer(addr string, ctrl *controlState, statsFn func() map[string]interface{}) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/pause", func(w http.ResponseWriter, r *http.Request) {
+		ctrl.paused.Store(true)
+		fmt.Fprintln(w, "paused")
+	})
+
+	mux.HandleFunc("/resume", func(w http.ResponseWriter, r *http.Request) {
+		ctrl.paused.Store(false)
+		fmt.Fprintln(w, "resumed")
+	})
+
+	mux.HandleFunc("/rate", func(w http.ResponseWriter, r *http.Request) {
+		ops, err := strconv.ParseFloat(r.URL.Query().Get("ops"), 64)
+		if err != nil {
+			http.Error(w, "invalid ops", http.StatusBadRequest)
+			return
+		}
+		ctrl.limiter.setRate(ops)
+		fmt.Fprintf(w, "rate set to %.2f ops/s\n", ops)
+	})
+
+	mux.HandleFunc("/concurrency", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.URL.Query().Get("n"))
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid n", http.StatusBadRequest)
+			return
+		}
+		ctrl.desired.Store(int64(n))
+		fmt.Fprintf(w, "concurrency set to %d\n", n)
+	})
+
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(statsFn())
+	})
+
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("Control server error: %v\n", err)
+		}
+	}()
+}
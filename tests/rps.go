@@ -9,17 +9,23 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math"
+	"math/bits"
 	"math/rand"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
+
+	"github.com/solenopsys/buddy-blocks/batch"
+	"github.com/solenopsys/buddy-blocks/bufs"
 )
 
 type config struct {
@@ -29,8 +35,30 @@ type config struct {
 	requestTimeout time.Duration
 	pushedFile     string
 	maxCount       int // Maximum number of objects to push (0 = unlimited)
+
+	failRate   float64       // Probability (0-1) of synthesizing a failed request
+	failStatus int           // Status code to report for a synthesized failure
+	maxRetries int           // Retries per operation before giving up
+	backoff    time.Duration // Initial backoff between retries
+	backoffMax time.Duration // Backoff ceiling
+
+	latencyCSV string // Optional path to write the final percentile report as CSV
+
+	mix          string  // PUT/GET/DELETE ratio for -op=mixed, e.g. "put:40,get:55,del:5"
+	distribution string  // Key selection distribution for -op=mixed: uniform or zipf
+	zipfSkew     float64 // Zipf skew parameter (s); higher = more skewed toward hot keys
+
+	batchSize int // Number of blocks grouped per request via the /batch endpoints (1 = single-block)
+
+	transport string // HTTP transport: nethttp (default) or fasthttp
+
+	controlAddr string  // Address for the control server, e.g. ":9000" (empty = disabled)
+	initialRate float64 // Initial -op=load ops/sec cap (0 = unlimited), adjustable at runtime via /rate
 }
 
+// numSizeBuckets is len(blockSizes); kept as a constant so it can size arrays.
+const numSizeBuckets = 8
+
 // Block sizes: 4KB, 8KB, 16KB, 32KB, 64KB, 128KB, 256KB, 512KB
 var blockSizes = []int{
 	4 * 1024,
@@ -44,19 +72,213 @@ var blockSizes = []int{
 }
 
 type blockRecord struct {
-	hash     string
-	data     []byte
-	sizeIdx  int
+	hash    string
+	data    []byte
+	sizeIdx int
+}
+
+// histBuckets covers latencies from ~1ns to ~2^63ns (well past the 60s range we
+// care about); bucket i holds the count of samples in [2^i, 2^(i+1)) ns.
+const histBuckets = 64
+
+// histogram is a lock-free logarithmic latency histogram: each sample only needs
+// an atomic increment on its bucket, so recording never blocks a worker.
+type histogram struct {
+	counts [histBuckets]uint64
+	max    uint64 // nanoseconds
+}
+
+func (h *histogram) record(d time.Duration) {
+	ns := uint64(d)
+	bucket := bits.Len64(ns)
+	if bucket >= histBuckets {
+		bucket = histBuckets - 1
+	}
+	atomic.AddUint64(&h.counts[bucket], 1)
+
+	for {
+		old := atomic.LoadUint64(&h.max)
+		if ns <= old {
+			return
+		}
+		if atomic.CompareAndSwapUint64(&h.max, old, ns) {
+			return
+		}
+	}
+}
+
+func (h *histogram) count() uint64 {
+	var total uint64
+	for i := range h.counts {
+		total += atomic.LoadUint64(&h.counts[i])
+	}
+	return total
+}
+
+// percentile returns the smallest bucket boundary at or above the p-th
+// percentile (p in [0, 1]). Accuracy is bounded by the bucket width, i.e.
+// within a factor of 2 of the true value.
+func (h *histogram) percentile(p float64) time.Duration {
+	total := h.count()
+	if total == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(p * float64(total)))
+	if target == 0 {
+		target = 1
+	}
+
+	var cum uint64
+	for i := range h.counts {
+		cum += atomic.LoadUint64(&h.counts[i])
+		if cum >= target {
+			return time.Duration(uint64(1) << uint(i))
+		}
+	}
+	return time.Duration(atomic.LoadUint64(&h.max))
+}
+
+// latencyStats holds per-op histograms, both overall and broken out by the
+// block-size bucket (index into blockSizes) the sample belongs to.
+type latencyStats struct {
+	put       histogram
+	get       histogram
+	del       histogram
+	putBySize [numSizeBuckets]histogram
+	getBySize [numSizeBuckets]histogram
+	delBySize [numSizeBuckets]histogram
+
+	batchPut histogram // whole-request latency for PUT /batch
+	batchGet histogram // whole-request latency for GET /batch
+}
+
+var latency latencyStats
+
+func (ls *latencyStats) recordPut(d time.Duration, sizeIdx int) {
+	ls.put.record(d)
+	if sizeIdx >= 0 && sizeIdx < len(ls.putBySize) {
+		ls.putBySize[sizeIdx].record(d)
+	}
+}
+
+func (ls *latencyStats) recordGet(d time.Duration, sizeIdx int) {
+	ls.get.record(d)
+	if sizeIdx >= 0 && sizeIdx < len(ls.getBySize) {
+		ls.getBySize[sizeIdx].record(d)
+	}
+}
+
+func (ls *latencyStats) recordDel(d time.Duration, sizeIdx int) {
+	ls.del.record(d)
+	if sizeIdx >= 0 && sizeIdx < len(ls.delBySize) {
+		ls.delBySize[sizeIdx].record(d)
+	}
+}
+
+func printLatencyReport(label string, h *histogram) {
+	if h.count() == 0 {
+		return
+	}
+	fmt.Printf("%s: p50=%v p95=%v p99=%v p999=%v max=%v (n=%d)\n",
+		label,
+		h.percentile(0.50), h.percentile(0.95), h.percentile(0.99), h.percentile(0.999),
+		time.Duration(atomic.LoadUint64(&h.max)), h.count())
+}
+
+func printLatencySummary(ls *latencyStats) {
+	fmt.Println("\n--- Latency ---")
+	printLatencyReport("PUT", &ls.put)
+	printLatencyReport("GET", &ls.get)
+	printLatencyReport("DEL", &ls.del)
+	printLatencyReport("PUT /batch", &ls.batchPut)
+	printLatencyReport("GET /batch", &ls.batchGet)
+	for i, size := range blockSizes {
+		printLatencyReport(fmt.Sprintf("PUT %dKB", size/1024), &ls.putBySize[i])
+	}
+	for i, size := range blockSizes {
+		printLatencyReport(fmt.Sprintf("GET %dKB", size/1024), &ls.getBySize[i])
+	}
+	for i, size := range blockSizes {
+		printLatencyReport(fmt.Sprintf("DEL %dKB", size/1024), &ls.delBySize[i])
+	}
+}
+
+// writeLatencyCSV writes one row per op/size-bucket combination so successive
+// runs can be diffed to catch regressions.
+func writeLatencyCSV(path string, ls *latencyStats) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "op,size_kb,count,p50_us,p95_us,p99_us,p999_us,max_us")
+	rows := []struct {
+		op     string
+		sizeKB string
+		h      *histogram
+	}{
+		{"PUT", "all", &ls.put},
+		{"GET", "all", &ls.get},
+		{"DEL", "all", &ls.del},
+	}
+	for i, size := range blockSizes {
+		rows = append(rows, struct {
+			op     string
+			sizeKB string
+			h      *histogram
+		}{"PUT", strconv.Itoa(size / 1024), &ls.putBySize[i]})
+	}
+	for i, size := range blockSizes {
+		rows = append(rows, struct {
+			op     string
+			sizeKB string
+			h      *histogram
+		}{"GET", strconv.Itoa(size / 1024), &ls.getBySize[i]})
+	}
+	for i, size := range blockSizes {
+		rows = append(rows, struct {
+			op     string
+			sizeKB string
+			h      *histogram
+		}{"DEL", strconv.Itoa(size / 1024), &ls.delBySize[i]})
+	}
+
+	toUS := func(d time.Duration) float64 { return float64(d.Nanoseconds()) / 1000 }
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s,%s,%d,%.1f,%.1f,%.1f,%.1f,%.1f\n",
+			row.op, row.sizeKB, row.h.count(),
+			toUS(row.h.percentile(0.50)), toUS(row.h.percentile(0.95)),
+			toUS(row.h.percentile(0.99)), toUS(row.h.percentile(0.999)),
+			toUS(time.Duration(atomic.LoadUint64(&row.h.max))))
+	}
+	return nil
 }
 
 func parseFlags() config {
 	cfg := config{}
 	flag.StringVar(&cfg.serverURL, "url", "http://localhost:10001", "Base server URL")
-	flag.StringVar(&cfg.operation, "op", "load", "Operation: load or check")
+	flag.StringVar(&cfg.operation, "op", "load", "Operation: load, check, or mixed")
 	flag.IntVar(&cfg.concurrency, "concurrency", runtime.NumCPU(), "Number of concurrent workers")
 	flag.DurationVar(&cfg.requestTimeout, "timeout", 10*time.Second, "Per-request timeout")
 	flag.StringVar(&cfg.pushedFile, "file", "pushed.txt", "File to store/read hashes")
 	flag.IntVar(&cfg.maxCount, "count", 0, "Maximum number of objects to push (0 = unlimited)")
+	flag.Float64Var(&cfg.failRate, "fail-rate", 0, "Probability (0-1) of injecting a synthetic request failure")
+	flag.IntVar(&cfg.failStatus, "fail-status", 503, "Status code reported for an injected failure")
+	flag.IntVar(&cfg.maxRetries, "max-retries", 0, "Retries per operation before giving up (0 = no retries)")
+	flag.DurationVar(&cfg.backoff, "backoff", 100*time.Millisecond, "Initial retry backoff (exponential with jitter)")
+	flag.DurationVar(&cfg.backoffMax, "backoff-max", 5*time.Second, "Maximum retry backoff")
+	flag.StringVar(&cfg.latencyCSV, "latency-csv", "", "Optional path to write the final latency percentiles as CSV")
+	flag.StringVar(&cfg.mix, "mix", "put:40,get:55,del:5", "PUT/GET/DELETE ratio for -op=mixed")
+	flag.StringVar(&cfg.distribution, "distribution", "uniform", "Key selection distribution for -op=mixed: uniform or zipf")
+	flag.Float64Var(&cfg.zipfSkew, "zipf-skew", 1.2, "Zipf skew parameter (s) for -distribution=zipf")
+	flag.IntVar(&cfg.batchSize, "batch", 1, "Blocks grouped per request via /batch (1 = single-block endpoints)")
+	flag.StringVar(&cfg.transport, "transport", "nethttp", "HTTP transport: nethttp or fasthttp")
+	flag.StringVar(&cfg.controlAddr, "control-addr", "", "Address for the control server, e.g. :9000 (empty = disabled)")
+	flag.Float64Var(&cfg.initialRate, "rate", 0, "Initial ops/sec cap for -op=load (0 = unlimited)")
 	flag.Parse()
 
 	if !strings.HasPrefix(cfg.serverURL, "http://") && !strings.HasPrefix(cfg.serverURL, "https://") {
@@ -92,9 +314,12 @@ func newHTTPClient(cfg config) *http.Client {
 	}
 }
 
+// generateRandomBlock draws its backing buffer from bufs so PUT doesn't
+// allocate a fresh []byte on every iteration. Callers are responsible for
+// returning data to the pool via bufs.Put once it's no longer needed.
 func generateRandomBlock(sizeIdx int, seed int64) blockRecord {
 	size := blockSizes[sizeIdx]
-	data := make([]byte, size)
+	data := bufs.Get(size)
 
 	r := rand.New(rand.NewSource(seed))
 	r.Read(data)
@@ -109,7 +334,91 @@ func generateRandomBlock(sizeIdx int, seed int64) blockRecord {
 	}
 }
 
-func doPut(client *http.Client, cfg config, payload []byte) (string, error) {
+var (
+	totalRetries    uint64
+	inducedFailures uint64
+)
+
+// injectedFailure decides, based on cfg.failRate, whether this attempt should be
+// synthesized as a failure instead of actually being dispatched.
+func injectedFailure(cfg config, rng *rand.Rand) error {
+	if cfg.failRate <= 0 {
+		return nil
+	}
+	if rng.Float64() >= cfg.failRate {
+		return nil
+	}
+	atomic.AddUint64(&inducedFailures, 1)
+	return fmt.Errorf("injected failure: status %d", cfg.failStatus)
+}
+
+// backoffSleep waits for d plus up to d of jitter, then returns the next backoff
+// to use (doubled, capped at cfg.backoffMax).
+func backoffSleep(cfg config, d time.Duration, rng *rand.Rand) time.Duration {
+	if d > 0 {
+		time.Sleep(d + time.Duration(rng.Int63n(int64(d)+1)))
+	}
+	next := d * 2
+	if next <= 0 {
+		next = cfg.backoff
+	}
+	if cfg.backoffMax > 0 && next > cfg.backoffMax {
+		next = cfg.backoffMax
+	}
+	return next
+}
+
+// putWithRetry calls doPut, retrying up to cfg.maxRetries times with exponential
+// backoff on error (including injected failures), and records the latency of
+// the overall (successful) call under the given block-size bucket.
+func putWithRetry(client *http.Client, cfg config, payload []byte, sizeIdx int, rng *rand.Rand) (string, error) {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		hash, err := doPut(client, cfg, payload, rng)
+		if err == nil {
+			latency.recordPut(time.Since(start), sizeIdx)
+			return hash, nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return "", lastErr
+}
+
+// getWithRetry calls doGet, retrying up to cfg.maxRetries times with exponential
+// backoff on error (including injected failures), and records the latency of
+// the overall (successful) call under the given block-size bucket.
+func getWithRetry(client *http.Client, cfg config, hash string, sizeIdx int, rng *rand.Rand) ([]byte, error) {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		data, err := doGet(client, cfg, hash, rng)
+		if err == nil {
+			latency.recordGet(time.Since(start), sizeIdx)
+			return data, nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return nil, lastErr
+}
+
+func doPut(client *http.Client, cfg config, payload []byte, rng *rand.Rand) (string, error) {
+	if err := injectedFailure(cfg, rng); err != nil {
+		return "", err
+	}
+
 	req, err := http.NewRequest("PUT", cfg.serverURL+"/", bytes.NewReader(payload))
 	if err != nil {
 		return "", err
@@ -138,7 +447,11 @@ func doPut(client *http.Client, cfg config, payload []byte) (string, error) {
 	return strings.TrimSpace(string(body)), nil
 }
 
-func doGet(client *http.Client, cfg config, hash string) ([]byte, error) {
+func doGet(client *http.Client, cfg config, hash string, rng *rand.Rand) ([]byte, error) {
+	if err := injectedFailure(cfg, rng); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("GET", cfg.serverURL+"/"+hash, nil)
 	if err != nil {
 		return nil, err
@@ -159,7 +472,199 @@ func doGet(client *http.Client, cfg config, hash string) ([]byte, error) {
 		return nil, fmt.Errorf("GET status %d: %s", resp.StatusCode, snippet)
 	}
 
-	return io.ReadAll(resp.Body)
+	// Read into a pooled buffer sized by Content-Length instead of
+	// io.ReadAll's grow-and-copy, falling back to io.ReadAll if the server
+	// didn't report a length. Callers return the buffer via bufs.Put once
+	// they're done with it.
+	if resp.ContentLength < 0 {
+		return io.ReadAll(resp.Body)
+	}
+	data := bufs.Get(int(resp.ContentLength))
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func doDelete(client *http.Client, cfg config, hash string, rng *rand.Rand) error {
+	if err := injectedFailure(cfg, rng); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", cfg.serverURL+"/"+hash, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("DELETE status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// deleteWithRetry calls doDelete, retrying up to cfg.maxRetries times with
+// exponential backoff on error (including injected failures).
+func deleteWithRetry(client *http.Client, cfg config, hash string, sizeIdx int, rng *rand.Rand) error {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		err := doDelete(client, cfg, hash, rng)
+		if err == nil {
+			latency.recordDel(time.Since(start), sizeIdx)
+			return nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return lastErr
+}
+
+// doBatchPut PUTs a framed batch to /batch and parses the newline-delimited
+// list of hashes returned in the same order as blocks.
+func doBatchPut(client *http.Client, cfg config, blocks [][]byte, rng *rand.Rand) ([]string, error) {
+	if err := injectedFailure(cfg, rng); err != nil {
+		return nil, err
+	}
+
+	body := batch.Encode(blocks)
+	req, err := http.NewRequest("PUT", cfg.serverURL+"/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		snippet := strings.TrimSpace(string(respBody))
+		if len(snippet) > 200 {
+			snippet = snippet[:200]
+		}
+		return nil, fmt.Errorf("PUT /batch status %d: %s", resp.StatusCode, snippet)
+	}
+
+	var hashes []string
+	scanner := bufio.NewScanner(bytes.NewReader(respBody))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			hashes = append(hashes, line)
+		}
+	}
+	if len(hashes) != len(blocks) {
+		return nil, fmt.Errorf("PUT /batch returned %d hashes, expected %d", len(hashes), len(blocks))
+	}
+	return hashes, nil
+}
+
+// doBatchGet requests a newline-delimited list of hashes from /batch and
+// decodes the framed response into the blocks, in order.
+func doBatchGet(client *http.Client, cfg config, hashes []string, rng *rand.Rand) ([][]byte, error) {
+	if err := injectedFailure(cfg, rng); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", cfg.serverURL+"/batch", strings.NewReader(strings.Join(hashes, "\n")))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		snippet := strings.TrimSpace(string(respBody))
+		if len(snippet) > 200 {
+			snippet = snippet[:200]
+		}
+		return nil, fmt.Errorf("GET /batch status %d: %s", resp.StatusCode, snippet)
+	}
+
+	blocks, err := batch.Decode(respBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) != len(hashes) {
+		return nil, fmt.Errorf("GET /batch returned %d blocks, expected %d", len(blocks), len(hashes))
+	}
+	return blocks, nil
+}
+
+// batchPutWithRetry calls doBatchPut, retrying the whole batch up to
+// cfg.maxRetries times on error, and records both the per-batch and
+// (approximate, evenly split) per-block latency.
+func batchPutWithRetry(client *http.Client, cfg config, blocks [][]byte, sizeIdx int, rng *rand.Rand) ([]string, error) {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		hashes, err := doBatchPut(client, cfg, blocks, rng)
+		if err == nil {
+			elapsed := time.Since(start)
+			latency.batchPut.record(elapsed)
+			latency.recordPut(elapsed/time.Duration(len(blocks)), sizeIdx)
+			return hashes, nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return nil, lastErr
+}
+
+// batchGetWithRetry calls doBatchGet, retrying the whole batch up to
+// cfg.maxRetries times on error, and records both the per-batch and
+// (approximate, evenly split) per-block latency.
+func batchGetWithRetry(client *http.Client, cfg config, hashes []string, sizeIdx int, rng *rand.Rand) ([][]byte, error) {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		blocks, err := doBatchGet(client, cfg, hashes, rng)
+		if err == nil {
+			elapsed := time.Since(start)
+			latency.batchGet.record(elapsed)
+			latency.recordGet(elapsed/time.Duration(len(hashes)), sizeIdx)
+			return blocks, nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return nil, lastErr
 }
 
 func runLoad(client *http.Client, cfg config) {
@@ -204,6 +709,23 @@ func runLoad(client *http.Client, cfg config) {
 
 	start := time.Now()
 
+	ctrl := newControlState(cfg.concurrency, cfg.initialRate)
+	if cfg.controlAddr != "" {
+		fmt.Printf("Control server: http://%s (pause/resume/rate/concurrency/stats)\n", cfg.controlAddr)
+		startControlServer(cfg.controlAddr, ctrl, func() map[string]interface{} {
+			return map[string]interface{}{
+				"total_ops":   atomic.LoadUint64(&totalOps),
+				"success_ops": atomic.LoadUint64(&successOps),
+				"failed_ops":  atomic.LoadUint64(&failedOps),
+				"total_bytes": atomic.LoadUint64(&totalBytes),
+				"paused":      ctrl.paused.Load(),
+				"concurrency": ctrl.desired.Load(),
+				"p50_put_us":  float64(latency.put.percentile(0.50).Microseconds()),
+				"p99_put_us":  float64(latency.put.percentile(0.99).Microseconds()),
+			}
+		})
+	}
+
 	// Stats printer
 	go func() {
 		ticker := time.NewTicker(5 * time.Second)
@@ -228,69 +750,121 @@ func runLoad(client *http.Client, cfg config) {
 		}
 	}()
 
-	// Workers
+	// Workers, scaled to ctrl.desired (fixed at cfg.concurrency unless a
+	// control server is attached and receives POST /concurrency).
 	var wg sync.WaitGroup
-	wg.Add(cfg.concurrency)
+	var nextWorkerID int64
 
-	for i := 0; i < cfg.concurrency; i++ {
-		workerID := i
-		go func() {
-			defer wg.Done()
-			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
+	worker := func(wctx context.Context) {
+		workerID := int(atomic.AddInt64(&nextWorkerID, 1))
+		r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
 
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+		for {
+			select {
+			case <-wctx.Done():
+				return
+			default:
+			}
 
-				// Check if we've reached maxCount
-				if cfg.maxCount > 0 && atomic.LoadUint64(&successOps) >= uint64(cfg.maxCount) {
-					return
-				}
+			ctrl.throttle(wctx)
 
-				// Random size index (0-7)
-				sizeIdx := r.Intn(len(blockSizes))
-				seed := time.Now().UnixNano() + int64(workerID)*1000000 + int64(atomic.LoadUint64(&totalOps))
+			// Check if we've reached maxCount
+			if cfg.maxCount > 0 && atomic.LoadUint64(&successOps) >= uint64(cfg.maxCount) {
+				return
+			}
 
-				block := generateRandomBlock(sizeIdx, seed)
+			if cfg.batchSize > 1 {
+				// Random size index (0-7), shared across the batch so the
+				// per-block latency bucket stays meaningful.
+				sizeIdx := r.Intn(len(blockSizes))
+				n := cfg.batchSize
+				blocks := make([][]byte, n)
+				records := make([]blockRecord, n)
+				for i := 0; i < n; i++ {
+					seed := time.Now().UnixNano() + int64(workerID)*1000000 + int64(atomic.LoadUint64(&totalOps)) + int64(i)
+					records[i] = generateRandomBlock(sizeIdx, seed)
+					blocks[i] = records[i].data
+				}
 
-				returnedHash, err := doPut(client, cfg, block.data)
-				atomic.AddUint64(&totalOps, 1)
+				hashes, err := batchPutWithRetry(client, cfg, blocks, sizeIdx, r)
+				atomic.AddUint64(&totalOps, uint64(n))
 
 				if err != nil {
-					atomic.AddUint64(&failedOps, 1)
-					fmt.Printf("PUT failed: %v\n", err)
-					fmt.Printf("\n✗ LOAD FAILED - stopping on first error\n")
-					os.Exit(1)
-				}
-
-				// Verify hash matches
-				if returnedHash != block.hash {
-					atomic.AddUint64(&failedOps, 1)
-					fmt.Printf("Hash mismatch! Expected: %s, Got: %s\n", block.hash, returnedHash)
+					atomic.AddUint64(&failedOps, uint64(n))
+					fmt.Printf("PUT /batch failed: %v\n", err)
 					fmt.Printf("\n✗ LOAD FAILED - stopping on first error\n")
 					os.Exit(1)
 				}
 
-				atomic.AddUint64(&successOps, 1)
-				atomic.AddUint64(&totalBytes, uint64(len(block.data)))
-
-				// Write to file
 				fileMu.Lock()
-				fmt.Fprintf(file, "%s %d\n", block.hash, sizeIdx)
+				for i, h := range hashes {
+					if h != records[i].hash {
+						fileMu.Unlock()
+						atomic.AddUint64(&failedOps, uint64(n))
+						fmt.Printf("Hash mismatch in batch! Expected: %s, Got: %s\n", records[i].hash, h)
+						fmt.Printf("\n✗ LOAD FAILED - stopping on first error\n")
+						os.Exit(1)
+					}
+					fmt.Fprintf(file, "%s %d\n", h, sizeIdx)
+				}
 				fileMu.Unlock()
 
-				// Stop after reaching maxCount
+				atomic.AddUint64(&successOps, uint64(n))
+				for _, rec := range records {
+					atomic.AddUint64(&totalBytes, uint64(len(rec.data)))
+					bufs.Put(rec.data)
+				}
+
 				if cfg.maxCount > 0 && atomic.LoadUint64(&successOps) >= uint64(cfg.maxCount) {
-					cancel() // Signal other workers to stop
+					cancel()
 					return
 				}
+				continue
 			}
-		}()
+
+			// Random size index (0-7)
+			sizeIdx := r.Intn(len(blockSizes))
+			seed := time.Now().UnixNano() + int64(workerID)*1000000 + int64(atomic.LoadUint64(&totalOps))
+
+			block := generateRandomBlock(sizeIdx, seed)
+
+			returnedHash, err := putWithRetry(client, cfg, block.data, sizeIdx, r)
+			atomic.AddUint64(&totalOps, 1)
+
+			if err != nil {
+				atomic.AddUint64(&failedOps, 1)
+				fmt.Printf("PUT failed: %v\n", err)
+				fmt.Printf("\n✗ LOAD FAILED - stopping on first error\n")
+				os.Exit(1)
+			}
+
+			// Verify hash matches
+			if returnedHash != block.hash {
+				atomic.AddUint64(&failedOps, 1)
+				fmt.Printf("Hash mismatch! Expected: %s, Got: %s\n", block.hash, returnedHash)
+				fmt.Printf("\n✗ LOAD FAILED - stopping on first error\n")
+				os.Exit(1)
+			}
+
+			atomic.AddUint64(&successOps, 1)
+			atomic.AddUint64(&totalBytes, uint64(len(block.data)))
+
+			// Write to file
+			fileMu.Lock()
+			fmt.Fprintf(file, "%s %d\n", block.hash, sizeIdx)
+			fileMu.Unlock()
+			bufs.Put(block.data)
+
+			// Stop after reaching maxCount
+			if cfg.maxCount > 0 && atomic.LoadUint64(&successOps) >= uint64(cfg.maxCount) {
+				cancel() // Signal other workers to stop
+				return
+			}
+		}
 	}
 
+	runControlled(ctx, ctrl, &wg, worker)
+
 	wg.Wait()
 
 	elapsed := time.Since(start)
@@ -304,10 +878,19 @@ func runLoad(client *http.Client, cfg config) {
 	fmt.Printf("Total ops: %d\n", total)
 	fmt.Printf("Success: %d\n", success)
 	fmt.Printf("Failed: %d\n", failed)
+	fmt.Printf("Retries: %d\n", atomic.LoadUint64(&totalRetries))
+	fmt.Printf("Induced failures: %d\n", atomic.LoadUint64(&inducedFailures))
 	fmt.Printf("Total data: %.2f MB\n", float64(bytes)/(1024*1024))
 	fmt.Printf("Average: %.2f ops/s | %.2f MB/s\n",
 		float64(total)/elapsed.Seconds(),
 		(float64(bytes)/(1024*1024))/elapsed.Seconds())
+
+	printLatencySummary(&latency)
+	if cfg.latencyCSV != "" {
+		if err := writeLatencyCSV(cfg.latencyCSV, &latency); err != nil {
+			fmt.Printf("Error writing latency CSV: %v\n", err)
+		}
+	}
 }
 
 func runCheck(client *http.Client, cfg config) {
@@ -371,56 +954,92 @@ func runCheck(client *http.Client, cfg config) {
 
 	start := time.Now()
 
-	// Work queue
-	jobs := make(chan hashEntry, len(entries))
-	for _, e := range entries {
-		jobs <- e
+	// Work queue, grouped into -batch sized chunks so a worker issues one
+	// /batch request per chunk instead of one request per hash.
+	batchSize := cfg.batchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	jobs := make(chan []hashEntry, (len(entries)+batchSize-1)/batchSize)
+	for off := 0; off < len(entries); off += batchSize {
+		end := off + batchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		jobs <- entries[off:end]
 	}
 	close(jobs)
 
+	verify := func(entry hashEntry, data []byte) bool {
+		sum := sha256.Sum256(data)
+		computedHash := hex.EncodeToString(sum[:])
+		if computedHash != entry.hash {
+			atomic.AddUint64(&hashMismatches, 1)
+			fmt.Printf("HASH MISMATCH! Expected: %s, Got: %s\n", entry.hash, computedHash)
+			fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
+			os.Exit(1)
+		}
+		expectedSize := blockSizes[entry.sizeIdx]
+		if len(data) != expectedSize {
+			atomic.AddUint64(&failedChecked, 1)
+			fmt.Printf("SIZE MISMATCH for %s! Expected: %d, Got: %d\n",
+				entry.hash, expectedSize, len(data))
+			fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
+			os.Exit(1)
+		}
+		return true
+	}
+
 	// Workers
 	var wg sync.WaitGroup
 	wg.Add(cfg.concurrency)
 
 	for i := 0; i < cfg.concurrency; i++ {
+		workerID := i
 		go func() {
 			defer wg.Done()
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
 
-			for entry := range jobs {
-				data, err := doGet(client, cfg, entry.hash)
-				atomic.AddUint64(&totalChecked, 1)
-
-				if err != nil {
-					atomic.AddUint64(&failedChecked, 1)
-					fmt.Printf("GET failed for %s: %v\n", entry.hash, err)
-					fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
-					os.Exit(1)
-				}
-
-				// Verify hash
-				sum := sha256.Sum256(data)
-				computedHash := hex.EncodeToString(sum[:])
-
-				if computedHash != entry.hash {
-					atomic.AddUint64(&hashMismatches, 1)
-					fmt.Printf("HASH MISMATCH! Expected: %s, Got: %s\n", entry.hash, computedHash)
-					fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
-					os.Exit(1)
-				}
-
-				// Verify size
-				expectedSize := blockSizes[entry.sizeIdx]
-				if len(data) != expectedSize {
-					atomic.AddUint64(&failedChecked, 1)
-					fmt.Printf("SIZE MISMATCH for %s! Expected: %d, Got: %d\n",
-						entry.hash, expectedSize, len(data))
-					fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
-					os.Exit(1)
+			for batch := range jobs {
+				if len(batch) > 1 {
+					hashes := make([]string, len(batch))
+					for i, e := range batch {
+						hashes[i] = e.hash
+					}
+
+					blocks, err := batchGetWithRetry(client, cfg, hashes, batch[0].sizeIdx, r)
+					atomic.AddUint64(&totalChecked, uint64(len(batch)))
+
+					if err != nil {
+						atomic.AddUint64(&failedChecked, uint64(len(batch)))
+						fmt.Printf("GET /batch failed: %v\n", err)
+						fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
+						os.Exit(1)
+					}
+
+					for i, entry := range batch {
+						verify(entry, blocks[i])
+						atomic.AddUint64(&successChecked, 1)
+						atomic.AddUint64(&totalBytes, uint64(len(blocks[i])))
+					}
+				} else {
+					entry := batch[0]
+					data, err := getWithRetry(client, cfg, entry.hash, entry.sizeIdx, r)
+					atomic.AddUint64(&totalChecked, 1)
+
+					if err != nil {
+						atomic.AddUint64(&failedChecked, 1)
+						fmt.Printf("GET failed for %s: %v\n", entry.hash, err)
+						fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
+						os.Exit(1)
+					}
+
+					verify(entry, data)
+					atomic.AddUint64(&successChecked, 1)
+					atomic.AddUint64(&totalBytes, uint64(len(data)))
+					bufs.Put(data)
 				}
 
-				atomic.AddUint64(&successChecked, 1)
-				atomic.AddUint64(&totalBytes, uint64(len(data)))
-
 				// Progress indicator
 				if atomic.LoadUint64(&totalChecked)%1000 == 0 {
 					fmt.Printf("Checked: %d/%d\n", atomic.LoadUint64(&totalChecked), len(entries))
@@ -444,11 +1063,20 @@ func runCheck(client *http.Client, cfg config) {
 	fmt.Printf("Success: %d\n", success)
 	fmt.Printf("Failed: %d\n", failed)
 	fmt.Printf("Hash mismatches: %d\n", mismatches)
+	fmt.Printf("Retries: %d\n", atomic.LoadUint64(&totalRetries))
+	fmt.Printf("Induced failures: %d\n", atomic.LoadUint64(&inducedFailures))
 	fmt.Printf("Total data verified: %.2f MB\n", float64(bytes)/(1024*1024))
 	fmt.Printf("Average: %.2f ops/s | %.2f MB/s\n",
 		float64(total)/elapsed.Seconds(),
 		(float64(bytes)/(1024*1024))/elapsed.Seconds())
 
+	printLatencySummary(&latency)
+	if cfg.latencyCSV != "" {
+		if err := writeLatencyCSV(cfg.latencyCSV, &latency); err != nil {
+			fmt.Printf("Error writing latency CSV: %v\n", err)
+		}
+	}
+
 	if success == total && mismatches == 0 && failed == 0 {
 		fmt.Printf("\n✓ ALL CHECKS PASSED!\n")
 	} else {
@@ -457,8 +1085,383 @@ func runCheck(client *http.Client, cfg config) {
 	}
 }
 
+// opWeights holds the relative PUT/GET/DELETE weights parsed from -mix.
+type opWeights struct {
+	put, get, del int
+}
+
+func parseMix(s string) (opWeights, error) {
+	var w opWeights
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return w, fmt.Errorf("invalid -mix entry %q, want op:weight", part)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || n < 0 {
+			return w, fmt.Errorf("invalid -mix weight in %q: %v", part, err)
+		}
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "put":
+			w.put = n
+		case "get":
+			w.get = n
+		case "del", "delete":
+			w.del = n
+		default:
+			return w, fmt.Errorf("unknown -mix op %q", kv[0])
+		}
+	}
+	if w.put+w.get+w.del <= 0 {
+		return w, fmt.Errorf("-mix weights must sum to > 0")
+	}
+	return w, nil
+}
+
+// pick returns "put", "get", or "del" according to the configured ratio.
+func (w opWeights) pick(r *rand.Rand) string {
+	n := r.Intn(w.put + w.get + w.del)
+	if n < w.put {
+		return "put"
+	}
+	n -= w.put
+	if n < w.get {
+		return "get"
+	}
+	return "del"
+}
+
+// keyEntry is a block known to exist on the server: its hash and the index
+// into blockSizes for its size.
+type keyEntry struct {
+	hash    string
+	sizeIdx int
+}
+
+// keyPool is the mutable set of known keys mixed-workload GETs/DELETEs are
+// drawn from: the hashes loaded from cfg.pushedFile plus every key PUT during
+// this run (so GETs can land on freshly-written, not-yet-persisted data).
+type keyPool struct {
+	mu      sync.Mutex
+	entries []keyEntry
+}
+
+func (kp *keyPool) add(e keyEntry) {
+	kp.mu.Lock()
+	kp.entries = append(kp.entries, e)
+	kp.mu.Unlock()
+}
+
+// removeHash deletes the first entry with the given hash via swap-with-last;
+// O(n) but keyed by hash rather than position, so it stays correct even if
+// the pool was mutated by another worker between pickAt and removeHash (an
+// index captured before the network round-trip in a delete can otherwise
+// point at a completely different entry by the time the delete completes).
+func (kp *keyPool) removeHash(hash string) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+	for i, e := range kp.entries {
+		if e.hash == hash {
+			n := len(kp.entries)
+			kp.entries[i] = kp.entries[n-1]
+			kp.entries = kp.entries[:n-1]
+			return
+		}
+	}
+}
+
+func (kp *keyPool) pickAt(idx int) (keyEntry, bool) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+	n := len(kp.entries)
+	if n == 0 {
+		return keyEntry{}, false
+	}
+	if idx >= n {
+		idx = n - 1
+	}
+	return kp.entries[idx], true
+}
+
+func (kp *keyPool) len() int {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+	return len(kp.entries)
+}
+
+func loadKeyPool(path string) *keyPool {
+	kp := &keyPool{}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return kp
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var hash string
+		var sizeIdx int
+		if _, err := fmt.Sscanf(line, "%s %d", &hash, &sizeIdx); err != nil {
+			continue
+		}
+		if sizeIdx < 0 || sizeIdx >= len(blockSizes) {
+			continue
+		}
+		kp.entries = append(kp.entries, keyEntry{hash: hash, sizeIdx: sizeIdx})
+	}
+
+	return kp
+}
+
+// zipfIndex draws a hot-skewed index in [0, n) using math/rand's Zipf
+// generator, recreating it only when the pool size has grown meaningfully
+// (Zipf's imax is fixed at construction).
+type zipfIndex struct {
+	r    *rand.Rand
+	skew float64
+	z    *rand.Zipf
+	imax uint64
+}
+
+func (zi *zipfIndex) next(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	imax := uint64(n - 1)
+	if zi.z == nil || imax > zi.imax+zi.imax/10 {
+		zi.z = rand.NewZipf(zi.r, zi.skew, 1, imax)
+		zi.imax = imax
+	}
+	idx := int(zi.z.Uint64())
+	if idx >= n {
+		idx = n - 1
+	}
+	return idx
+}
+
+func pickIndex(cfg config, zi *zipfIndex, r *rand.Rand, n int) int {
+	if cfg.distribution == "zipf" {
+		return zi.next(n)
+	}
+	return r.Intn(n)
+}
+
+func runMixed(client *http.Client, cfg config) {
+	weights, err := parseMix(cfg.mix)
+	if err != nil {
+		fmt.Printf("Error parsing -mix: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("=== MIXED Mode ===\n")
+	fmt.Printf("Server: %s\n", cfg.serverURL)
+	fmt.Printf("Concurrency: %d\n", cfg.concurrency)
+	fmt.Printf("Mix: put=%d get=%d del=%d\n", weights.put, weights.get, weights.del)
+	fmt.Printf("Distribution: %s\n", cfg.distribution)
+	if cfg.maxCount > 0 {
+		fmt.Printf("Max ops: %d\n", cfg.maxCount)
+	} else {
+		fmt.Printf("Press Ctrl+C to stop\n")
+	}
+	fmt.Println()
+
+	pool := loadKeyPool(cfg.pushedFile)
+	fmt.Printf("Loaded %d existing keys from %s\n\n", pool.len(), cfg.pushedFile)
+
+	file, err := os.OpenFile(cfg.pushedFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("Error opening file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+	var fileMu sync.Mutex
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		fmt.Println("\n\nReceived interrupt signal, stopping...")
+		cancel()
+	}()
+
+	var totalOps uint64
+	var putOps, getOps, delOps uint64
+	var putErrs, getErrs, delErrs uint64
+	var totalBytes uint64
+
+	start := time.Now()
+
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				elapsed := time.Since(start).Seconds()
+				total := atomic.LoadUint64(&totalOps)
+				rps := float64(total) / elapsed
+				fmt.Printf("[%.0fs] Total: %d | PUT: %d | GET: %d | DEL: %d | %.2f ops/s\n",
+					elapsed, total, atomic.LoadUint64(&putOps), atomic.LoadUint64(&getOps),
+					atomic.LoadUint64(&delOps), rps)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(cfg.concurrency)
+
+	for i := 0; i < cfg.concurrency; i++ {
+		workerID := i
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
+			zi := &zipfIndex{r: r, skew: cfg.zipfSkew}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				if cfg.maxCount > 0 && atomic.LoadUint64(&totalOps) >= uint64(cfg.maxCount) {
+					return
+				}
+
+				switch weights.pick(r) {
+				case "put":
+					sizeIdx := r.Intn(len(blockSizes))
+					seed := time.Now().UnixNano() + int64(workerID)*1000000 + int64(atomic.LoadUint64(&totalOps))
+					block := generateRandomBlock(sizeIdx, seed)
+
+					returnedHash, err := putWithRetry(client, cfg, block.data, sizeIdx, r)
+					if err != nil || returnedHash != block.hash {
+						atomic.AddUint64(&putErrs, 1)
+						bufs.Put(block.data)
+						break
+					}
+					atomic.AddUint64(&putOps, 1)
+					atomic.AddUint64(&totalBytes, uint64(len(block.data)))
+
+					pool.add(keyEntry{hash: block.hash, sizeIdx: sizeIdx})
+					fileMu.Lock()
+					fmt.Fprintf(file, "%s %d\n", block.hash, sizeIdx)
+					fileMu.Unlock()
+					bufs.Put(block.data)
+
+				case "get":
+					n := pool.len()
+					if n == 0 {
+						break
+					}
+					entry, ok := pool.pickAt(pickIndex(cfg, zi, r, n))
+					if !ok {
+						break
+					}
+					data, err := getWithRetry(client, cfg, entry.hash, entry.sizeIdx, r)
+					if err != nil || len(data) != blockSizes[entry.sizeIdx] {
+						atomic.AddUint64(&getErrs, 1)
+						bufs.Put(data)
+						break
+					}
+					atomic.AddUint64(&getOps, 1)
+					atomic.AddUint64(&totalBytes, uint64(len(data)))
+					bufs.Put(data)
+
+				case "del":
+					n := pool.len()
+					if n == 0 {
+						break
+					}
+					entry, ok := pool.pickAt(pickIndex(cfg, zi, r, n))
+					if !ok {
+						break
+					}
+					if err := deleteWithRetry(client, cfg, entry.hash, entry.sizeIdx, r); err != nil {
+						atomic.AddUint64(&delErrs, 1)
+						break
+					}
+					atomic.AddUint64(&delOps, 1)
+					pool.removeHash(entry.hash)
+				}
+
+				atomic.AddUint64(&totalOps, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	total := atomic.LoadUint64(&totalOps)
+	bytes := atomic.LoadUint64(&totalBytes)
+
+	fmt.Printf("\n=== MIXED Complete ===\n")
+	fmt.Printf("Duration: %.2fs\n", elapsed.Seconds())
+	fmt.Printf("Total ops: %d\n", total)
+	fmt.Printf("PUT: %d (errors: %d)\n", atomic.LoadUint64(&putOps), atomic.LoadUint64(&putErrs))
+	fmt.Printf("GET: %d (errors: %d)\n", atomic.LoadUint64(&getOps), atomic.LoadUint64(&getErrs))
+	fmt.Printf("DEL: %d (errors: %d)\n", atomic.LoadUint64(&delOps), atomic.LoadUint64(&delErrs))
+	fmt.Printf("Retries: %d\n", atomic.LoadUint64(&totalRetries))
+	fmt.Printf("Induced failures: %d\n", atomic.LoadUint64(&inducedFailures))
+	fmt.Printf("Average: %.2f ops/s | %.2f MB/s\n",
+		float64(total)/elapsed.Seconds(),
+		(float64(bytes)/(1024*1024))/elapsed.Seconds())
+
+	printLatencySummary(&latency)
+	if cfg.latencyCSV != "" {
+		if err := writeLatencyCSV(cfg.latencyCSV, &latency); err != nil {
+			fmt.Printf("Error writing latency CSV: %v\n", err)
+		}
+	}
+}
+
+// runLoadFastHTTP and runCheckFastHTTP are wired up by rps_fasthttp.go when
+// built with the fasthttp build tag (`go build -tags fasthttp`); they stay
+// nil in the default build, which depends only on the standard library.
+var (
+	runLoadFastHTTP  func(cfg config)
+	runCheckFastHTTP func(cfg config)
+)
+
 func main() {
 	cfg := parseFlags()
+
+	if cfg.transport == "fasthttp" {
+		switch cfg.operation {
+		case "load":
+			if runLoadFastHTTP == nil {
+				fmt.Println("Built without fasthttp support; rebuild with -tags fasthttp")
+				os.Exit(1)
+			}
+			runLoadFastHTTP(cfg)
+		case "check":
+			if runCheckFastHTTP == nil {
+				fmt.Println("Built without fasthttp support; rebuild with -tags fasthttp")
+				os.Exit(1)
+			}
+			runCheckFastHTTP(cfg)
+		default:
+			fmt.Printf("-transport=fasthttp only supports -op=load and -op=check\n")
+			os.Exit(1)
+		}
+		return
+	}
+
 	client := newHTTPClient(cfg)
 
 	switch cfg.operation {
@@ -466,9 +1469,11 @@ func main() {
 		runLoad(client, cfg)
 	case "check":
 		runCheck(client, cfg)
+	case "mixed":
+		runMixed(client, cfg)
 	default:
 		fmt.Printf("Unknown operation: %s\n", cfg.operation)
-		fmt.Println("Use -op=load or -op=check")
+		fmt.Println("Use -op=load, -op=check, or -op=mixed")
 		os.Exit(1)
 	}
 }
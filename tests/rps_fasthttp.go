@@ -0,0 +1,330 @@
+//go:build fasthttp
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+func init() {
+	runLoadFastHTTP = runLoadFastHTTPImpl
+	runCheckFastHTTP = runCheckFastHTTPImpl
+}
+
+// payloadPools holds one sync.Pool per block size so workers reuse the same
+// backing array across iterations instead of allocating via make([]byte, size).
+var payloadPools [numSizeBuckets]sync.Pool
+
+func init() {
+	for i, size := range blockSizes {
+		size := size
+		payloadPools[i] = sync.Pool{
+			New: func() interface{} { return make([]byte, size) },
+		}
+	}
+}
+
+func acquirePayload(sizeIdx int) []byte {
+	return payloadPools[sizeIdx].Get().([]byte)
+}
+
+func releasePayload(sizeIdx int, b []byte) {
+	payloadPools[sizeIdx].Put(b) //nolint:staticcheck // pool key is the fixed block size
+}
+
+func newFastHTTPClient(cfg config) *fasthttp.Client {
+	return &fasthttp.Client{
+		MaxConnsPerHost:     cfg.concurrency * 2,
+		ReadTimeout:         cfg.requestTimeout,
+		WriteTimeout:        cfg.requestTimeout,
+		MaxIdleConnDuration: 60 * time.Second,
+	}
+}
+
+// fastPut honors the same -fail-rate fault injection as doPut, then refills
+// buf with random bytes, hashes it in place, and PUTs it using a pooled
+// fasthttp request/response pair.
+func fastPut(client *fasthttp.Client, cfg config, buf []byte, r *rand.Rand) (hash string, err error) {
+	if err := injectedFailure(cfg, r); err != nil {
+		return "", err
+	}
+
+	r.Read(buf)
+	sum := sha256.Sum256(buf)
+	hash = hex.EncodeToString(sum[:])
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(cfg.serverURL + "/")
+	req.Header.SetMethod(fasthttp.MethodPut)
+	req.SetBody(buf)
+
+	if err := client.DoTimeout(req, resp, cfg.requestTimeout); err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != fasthttp.StatusOK {
+		return "", fmt.Errorf("PUT status %d", resp.StatusCode())
+	}
+
+	returned := string(bytesTrimSpace(resp.Body()))
+	if returned != hash {
+		return "", fmt.Errorf("hash mismatch: expected %s, got %s", hash, returned)
+	}
+	return hash, nil
+}
+
+// putWithRetryFastHTTP mirrors putWithRetry for the fasthttp transport:
+// retries fastPut up to cfg.maxRetries times with exponential backoff on
+// error (including injected failures), recording the latency of the
+// overall (successful) call under the given block-size bucket.
+func putWithRetryFastHTTP(client *fasthttp.Client, cfg config, buf []byte, sizeIdx int, rng *rand.Rand) (string, error) {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		hash, err := fastPut(client, cfg, buf, rng)
+		if err == nil {
+			latency.recordPut(time.Since(start), sizeIdx)
+			return hash, nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return "", lastErr
+}
+
+// fastGet honors the same -fail-rate fault injection as doGet, then fetches
+// hash into a pooled buffer of the expected size. A server that returns a
+// body longer than sizeIdx's block size is reported as an error instead of
+// being copied into (and overflowing) the fixed-capacity pooled buffer.
+func fastGet(client *fasthttp.Client, cfg config, hash string, sizeIdx int, r *rand.Rand) ([]byte, error) {
+	if err := injectedFailure(cfg, r); err != nil {
+		return nil, err
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(cfg.serverURL + "/" + hash)
+	req.Header.SetMethod(fasthttp.MethodGet)
+
+	if err := client.DoTimeout(req, resp, cfg.requestTimeout); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != fasthttp.StatusOK {
+		return nil, fmt.Errorf("GET status %d", resp.StatusCode())
+	}
+
+	body := resp.Body()
+	buf := acquirePayload(sizeIdx)
+	if len(body) > cap(buf) {
+		releasePayload(sizeIdx, buf)
+		return nil, fmt.Errorf("GET %s: body length %d exceeds expected block size %d", hash, len(body), cap(buf))
+	}
+	buf = buf[:len(body)]
+	copy(buf, body)
+	return buf, nil
+}
+
+// getWithRetryFastHTTP mirrors getWithRetry for the fasthttp transport:
+// retries fastGet up to cfg.maxRetries times with exponential backoff on
+// error (including injected failures), recording the latency of the
+// overall (successful) call under the given block-size bucket.
+func getWithRetryFastHTTP(client *fasthttp.Client, cfg config, hash string, sizeIdx int, rng *rand.Rand) ([]byte, error) {
+	start := time.Now()
+	backoff := cfg.backoff
+	var lastErr error
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		data, err := fastGet(client, cfg, hash, sizeIdx, rng)
+		if err == nil {
+			latency.recordGet(time.Since(start), sizeIdx)
+			return data, nil
+		}
+		lastErr = err
+		if attempt == cfg.maxRetries {
+			break
+		}
+		atomic.AddUint64(&totalRetries, 1)
+		backoff = backoffSleep(cfg, backoff, rng)
+	}
+	return nil, lastErr
+}
+
+func bytesTrimSpace(b []byte) []byte {
+	start, end := 0, len(b)
+	for start < end && (b[start] == ' ' || b[start] == '\n' || b[start] == '\r' || b[start] == '\t') {
+		start++
+	}
+	for end > start && (b[end-1] == ' ' || b[end-1] == '\n' || b[end-1] == '\r' || b[end-1] == '\t') {
+		end--
+	}
+	return b[start:end]
+}
+
+func runLoadFastHTTPImpl(cfg config) {
+	fmt.Printf("=== LOAD Mode (fasthttp transport) ===\n")
+	fmt.Printf("Server: %s\n", cfg.serverURL)
+	fmt.Printf("Concurrency: %d\n", cfg.concurrency)
+	if cfg.maxCount > 0 {
+		fmt.Printf("Max objects: %d\n", cfg.maxCount)
+	} else {
+		fmt.Printf("Press Ctrl+C to stop\n")
+	}
+	fmt.Println()
+
+	client := newFastHTTPClient(cfg)
+
+	file, err := os.OpenFile(cfg.pushedFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("Error opening file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+	var fileMu sync.Mutex
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	stop := make(chan struct{})
+	go func() {
+		<-sigChan
+		fmt.Println("\n\nReceived interrupt signal, stopping...")
+		close(stop)
+	}()
+
+	var successOps, failedOps uint64
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	wg.Add(cfg.concurrency)
+	for i := 0; i < cfg.concurrency; i++ {
+		workerID := i
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if cfg.maxCount > 0 && atomic.LoadUint64(&successOps) >= uint64(cfg.maxCount) {
+					return
+				}
+
+				sizeIdx := r.Intn(len(blockSizes))
+				buf := acquirePayload(sizeIdx)
+
+				hash, err := putWithRetryFastHTTP(client, cfg, buf, sizeIdx, r)
+				releasePayload(sizeIdx, buf)
+
+				if err != nil {
+					atomic.AddUint64(&failedOps, 1)
+					fmt.Printf("PUT failed: %v\n", err)
+					fmt.Printf("\n✗ LOAD FAILED - stopping on first error\n")
+					os.Exit(1)
+				}
+
+				atomic.AddUint64(&successOps, 1)
+
+				fileMu.Lock()
+				fmt.Fprintf(file, "%s %d\n", hash, sizeIdx)
+				fileMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	fmt.Printf("\n=== LOAD Complete (fasthttp) ===\n")
+	fmt.Printf("Duration: %.2fs\n", elapsed.Seconds())
+	fmt.Printf("Success: %d\n", atomic.LoadUint64(&successOps))
+	fmt.Printf("Failed: %d\n", atomic.LoadUint64(&failedOps))
+	fmt.Printf("Retries: %d\n", atomic.LoadUint64(&totalRetries))
+	fmt.Printf("Induced failures: %d\n", atomic.LoadUint64(&inducedFailures))
+	fmt.Printf("Average: %.2f ops/s\n", float64(atomic.LoadUint64(&successOps))/elapsed.Seconds())
+	printLatencySummary(&latency)
+}
+
+func runCheckFastHTTPImpl(cfg config) {
+	fmt.Printf("=== CHECK Mode (fasthttp transport) ===\n")
+	fmt.Printf("Server: %s\n", cfg.serverURL)
+	fmt.Printf("Concurrency: %d\n", cfg.concurrency)
+	fmt.Printf("Input file: %s\n\n", cfg.pushedFile)
+
+	pool := loadKeyPool(cfg.pushedFile)
+	fmt.Printf("Loaded %d hashes from file\n\n", pool.len())
+
+	client := newFastHTTPClient(cfg)
+
+	var successOps, failedOps uint64
+	start := time.Now()
+
+	jobs := make(chan keyEntry, pool.len())
+	for i := 0; i < pool.len(); i++ {
+		e, _ := pool.pickAt(i)
+		jobs <- e
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(cfg.concurrency)
+	for i := 0; i < cfg.concurrency; i++ {
+		workerID := i
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
+
+			for entry := range jobs {
+				data, err := getWithRetryFastHTTP(client, cfg, entry.hash, entry.sizeIdx, r)
+				if err != nil {
+					atomic.AddUint64(&failedOps, 1)
+					fmt.Printf("GET failed for %s: %v\n", entry.hash, err)
+					fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
+					os.Exit(1)
+				}
+
+				sum := sha256.Sum256(data)
+				if hex.EncodeToString(sum[:]) != entry.hash {
+					atomic.AddUint64(&failedOps, 1)
+					fmt.Printf("HASH MISMATCH for %s\n", entry.hash)
+					fmt.Printf("\n✗ CHECK FAILED - stopping on first error\n")
+					os.Exit(1)
+				}
+				releasePayload(entry.sizeIdx, data)
+				atomic.AddUint64(&successOps, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	fmt.Printf("\n=== CHECK Complete (fasthttp) ===\n")
+	fmt.Printf("Duration: %.2fs\n", elapsed.Seconds())
+	fmt.Printf("Success: %d\n", atomic.LoadUint64(&successOps))
+	fmt.Printf("Failed: %d\n", atomic.LoadUint64(&failedOps))
+	fmt.Printf("Retries: %d\n", atomic.LoadUint64(&totalRetries))
+	fmt.Printf("Induced failures: %d\n", atomic.LoadUint64(&inducedFailures))
+	fmt.Printf("Average: %.2f ops/s\n", float64(atomic.LoadUint64(&successOps))/elapsed.Seconds())
+	printLatencySummary(&latency)
+}
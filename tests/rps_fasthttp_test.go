@@ -0,0 +1,68 @@
+//go:build fasthttp
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFastHTTPAllocsPerRun verifies fastPut/fastGet allocate ~0 bytes per op
+// once the pooled payload and request/response objects have warmed up, so
+// ops/sec measured under -transport=fasthttp reflects server capacity rather
+// than client-side GC pressure.
+func TestFastHTTPAllocsPerRun(t *testing.T) {
+	sizeIdx := 0
+	size := blockSizes[sizeIdx]
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body := make([]byte, size)
+			io.ReadFull(r.Body, body)
+			sum := sha256.Sum256(body)
+			w.Write([]byte(hex.EncodeToString(sum[:])))
+		case http.MethodGet:
+			w.Write(make([]byte, size))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := config{serverURL: srv.URL, requestTimeout: 5 * time.Second, concurrency: 1}
+	client := newFastHTTPClient(cfg)
+	r := rand.New(rand.NewSource(1))
+
+	buf := acquirePayload(sizeIdx)
+	defer releasePayload(sizeIdx, buf)
+
+	var hash string
+	putAllocs := testing.AllocsPerRun(100, func() {
+		h, err := fastPut(client, cfg, buf, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash = h
+	})
+	if putAllocs > 1 {
+		t.Fatalf("fastPut allocated %.1f allocs/op, want ~0", putAllocs)
+	}
+
+	getAllocs := testing.AllocsPerRun(100, func() {
+		data, err := fastGet(client, cfg, hash, sizeIdx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releasePayload(sizeIdx, data)
+	})
+	if getAllocs > 1 {
+		t.Fatalf("fastGet allocated %.1f allocs/op, want ~0", getAllocs)
+	}
+}